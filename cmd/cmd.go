@@ -1,25 +1,35 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
-	"log"
 	"os"
 	"runtime/debug"
+	"strings"
 
 	"github.com/spf13/afero"
 
-	"github.com/Marble-Technologies/type-wrapper/internal/wrapper"
+	"github.com/Marble-Technologies/type-wrapper/wrapper"
 )
 
 // Version is the version of `type-wrapper`, injected at build time.
 var Version = ""
 
+const (
+	// generateCommand is the subcommand running the go:generate directives of packages.
+	generateCommand = "generate"
+	// configCommand is the subcommand printing the settings resolved from the configuration file.
+	configCommand = "config"
+)
+
 // newUsage returns a function to replace default usage function of FlagSet.
 func newUsage(flags *flag.FlagSet) func() {
 	return func() {
 		fmt.Fprintf(os.Stderr, "Usage of type-wrapper:\n")
-		fmt.Fprintf(os.Stderr, "\ttype-wrapper [flags] [directory]\n")
+		fmt.Fprintf(os.Stderr, "\ttype-wrapper [flags] [packages]\n")
+		fmt.Fprintf(os.Stderr, "\ttype-wrapper generate [-format text|json] [packages]\n")
+		fmt.Fprintf(os.Stderr, "\ttype-wrapper config print -type name [flags] [packages]\n")
 		fmt.Fprintf(os.Stderr, "For more information, see:\n")
 		fmt.Fprintf(os.Stderr, "\thttps://github.com/Marble-Technologies/type-wrapper\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
@@ -27,81 +37,242 @@ func newUsage(flags *flag.FlagSet) func() {
 	}
 }
 
-// Execute executes a whole process of generating wrapper codes.
-func Execute(fs afero.Fs, args []string) {
-	log.SetFlags(0 | log.Lshortfile)
-	log.SetPrefix("type-wrapper: ")
+// usageError prints err and the usage of flags, and returns err.
+func usageError(flags *flag.FlagSet, err error) error {
+	fmt.Fprintf(os.Stderr, "%s: %s\n", commandName, err)
+	flags.Usage()
+	return err
+}
+
+// config holds the flags of a type-wrapper command, either given on the
+// command line or in a go:generate directive.
+type config struct {
+	version         bool
+	reader          bool
+	writer          bool
+	registry        bool
+	codec           string
+	codecTag        string
+	typeNames       string
+	all             bool
+	wrapperTypeName string
+	interfaceName   string
+	lockName        string
+	receiver        string
+	pointer         bool
+	embeddedPtrs    bool
+	defensiveCopy   bool
+	changeHooks     bool
+	trackChanges    bool
+	changedJSON     bool
+	output          string
+	collisions      string
+	templates       string
+	check           bool
+	dryRun          bool
+	format          string
+
+	explicit *settings // settings of the flags set explicitly, which override the configuration file
+}
+
+// newFlagSet returns the flags of a type-wrapper command named name, and the config they set.
+func newFlagSet(name string) (*flag.FlagSet, *config) {
+	c := new(config)
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.BoolVar(&c.version, "version", false, "show the version of wrap")
+	flags.BoolVar(&c.reader, "reader", false, "implement io.Reader, io.WriterTo and json.Marshaler interfaces")
+	flags.BoolVar(&c.writer, "writer", false, "implement io.Writer and json.Unmarshaler interfaces")
+	flags.BoolVar(&c.registry, "registry", false, "register wrappers in a registry decoding payloads by _data_type")
+	flags.StringVar(&c.codec, "codec", "json", "codec used by -reader and -writer: json, xml, gob or the qualified name of a Marshal function")
+	flags.StringVar(&c.codecTag, "codec-tag", "", "struct tag key of a custom codec; default the package name of the codec")
+	flags.StringVar(&c.typeNames, "type", "", "comma-separated list of type names, which may be qualified by the import path of another package; must be set unless -all is set")
+	flags.BoolVar(&c.all, "all", false, "generate wrappers for every struct with a wrapper tag")
+	flags.StringVar(&c.wrapperTypeName, "wrapper", "", "wrapper type name or pattern; default {{.Type}}Wrapper")
+	flags.StringVar(&c.interfaceName, "interface", "", "wrapper interface name or pattern such as I{{.Type}} to be generated")
+	flags.StringVar(&c.lockName, "lock", "", "lock field name; the field must implement sync.Locker")
+	flags.StringVar(&c.receiver, "receiver", "", "receiver name; default first letter of type name")
+	flags.BoolVar(&c.pointer, "pointer", false, "generate methods with pointer receivers")
+	flags.BoolVar(&c.changeHooks, "change-hooks", false, "generate OnChange and On<Field>Change methods registering hooks, which setters call with the old and new values")
+	flags.BoolVar(&c.trackChanges, "track-changes", false, "mark the fields set by setters changed, and generate Changed, IsChanged and ClearChanges methods")
+	flags.BoolVar(&c.changedJSON, "changed-json", false, "generate a ChangedJSON method encoding the changed fields by their json tag names; implies -track-changes")
+	flags.BoolVar(&c.defensiveCopy, "defensive-copy", false, "copy the values of slices, maps and pointers in every getter and setter, and generate a Clone method")
+	flags.BoolVar(&c.embeddedPtrs, "embedded-pointers", false, "generate accessors for tagged fields promoted through embedded pointers, checking the pointers for nil")
+	flags.StringVar(&c.output, "output", "", "output file name, or - to write to stdout; default <type_name>_wrapper.go")
+	flags.StringVar(&c.collisions, "collisions", wrapper.CollisionError, "policy for generated methods colliding with other methods or fields of the wrapper: error, skip or rename")
+	flags.StringVar(&c.templates, "templates", "", "directory of templates overriding the built-in ones, such as getter.tmpl")
+	flags.BoolVar(&c.check, "check", false, "print a diff and exit with status 1 if the wrappers are stale, without writing them")
+	flags.BoolVar(&c.dryRun, "dry-run", false, "print the files that would be created or changed and their number of methods, without writing them")
+	flags.StringVar(&c.format, "format", formatText, "format of the problems reported on stderr: text, or json for an array of diagnostics")
+
+	return flags, c
+}
+
+// types returns the type names of the -type flag.
+func (c *config) types() []string {
+	return splitTypeNames(c.typeNames)
+}
 
-	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+// Execute executes a whole process of generating wrapper codes. It returns
+// Diagnostics with the problems it reported, ErrStale if -check finds stale
+// wrappers, or the error of invalid arguments.
+func Execute(fs afero.Fs, args []string) error {
+	if len(args) > 2 && args[1] == configCommand && args[2] == "print" {
+		return printConfig(args[3:])
+	}
+
+	if len(args) > 1 && args[1] == generateCommand {
+		return executeDirectives(fs, args[2:])
+	}
+
+	flags, c := newFlagSet(args[0])
 	flags.Usage = newUsage(flags)
-	version := flags.Bool("version", false, "show the version of wrap")
-	reader := flags.Bool("reader", false, "implement io.Reader interface")
-	typeName := flags.String("type", "", "type name; must be set")
-	wrapperTypeName := flags.String("wrapper", "", "wrapper type name; default <type_name>Wrapper")
-	interfaceName := flags.String("interface", "", "wrapper interface name to be generated")
-	lockName := flags.String("lock", "", "lock name")
-	receiver := flags.String("receiver", "", "receiver name; default first letter of type name")
-	output := flags.String("output", "", "output file name; default <type_name>_wrapper.go")
 
 	if err := flags.Parse(args[1:]); err != nil {
-		flags.Usage()
-		os.Exit(1)
+		return err
+	}
+	c.explicit = flagSettings(flags, c, false)
+	r, err := newReporter(c.format, os.Stderr)
+	if err != nil {
+		return usageError(flags, err)
 	}
 
-	if *version {
+	if c.version {
 		fmt.Fprintf(os.Stdout, "type-wrapper version: %s\n", getVersion())
-		os.Exit(0)
+		return nil
 	}
 
-	if typeName == nil || len(*typeName) == 0 {
-		flags.Usage()
-		os.Exit(1)
-	}
-	if wrapperTypeName == nil || len(*wrapperTypeName) == 0 {
-		*wrapperTypeName = *typeName + "Wrapper"
+	if len(c.types()) == 0 && !c.all {
+		return usageError(flags, errors.New("-type or -all must be set"))
 	}
 
-	var dir string
-	if cliArgs := flags.Args(); len(cliArgs) > 0 {
-		dir = cliArgs[0]
-	} else {
+	patterns := flags.Args()
+	if len(patterns) == 0 {
 		// Default: process whole package in current directory.
-		dir = "."
+		patterns = []string{"."}
 	}
 
-	if !isDir(dir) {
-		fmt.Fprintln(os.Stderr, "Specified argument is not a directory.")
-		flags.Usage()
-		os.Exit(1)
+	pkgs, err := wrapper.ParsePackages(patterns...)
+	if err != nil {
+		r.report(err)
+		return r.result(false)
 	}
 
-	pkg, err := wrapper.ParsePackage(dir)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		flags.Usage()
-		os.Exit(1)
+	return r.result(run(fs, pkgs, c, newConfigLoader(), r))
+}
+
+const (
+	statusOK    = "ok"
+	statusStale = "stale"
+	statusFail  = "FAIL"
+)
+
+// run runs the command configured by c and the configuration files of configs on pkgs,
+// reports its problems to r, and reports whether it succeeded and every wrapper is up
+// to date. With several packages, each package gets the wrappers of the types it
+// declares, and a summary line is printed for each package.
+func run(fs afero.Fs, pkgs []*wrapper.Package, c *config, configs *configLoader, r *reporter) bool {
+	types := c.types()
+	if len(pkgs) > 1 {
+		for _, typ := range types {
+			if wrapper.IsQualifiedTypeName(typ) {
+				r.report(fmt.Errorf("type %s of another package needs a single target package", typ))
+				return false
+			}
+		}
 	}
 
-	var options = []wrapper.Option{
-		wrapper.Type(*typeName),
-		wrapper.Output(*output),
-		wrapper.Receiver(*receiver),
-		wrapper.Lock(*lockName),
-		wrapper.Reader(*reader),
-		wrapper.Wrapper(*wrapperTypeName),
-		wrapper.Interface(*interfaceName),
+	ok := true
+	found := make(map[string]bool, len(types))
+	for _, pkg := range pkgs {
+		pkgTypes := types
+		if len(pkgs) > 1 {
+			// generate the types each package declares, and skip the others
+			pkgTypes = nil
+			for _, typ := range types {
+				if pkg.HasStruct(typ) {
+					pkgTypes = append(pkgTypes, typ)
+					found[typ] = true
+				}
+			}
+			if len(pkgTypes) == 0 && (!c.all || !pkg.HasTaggedStruct()) {
+				continue
+			}
+		}
+
+		status, err := generate(fs, pkg, pkgTypes, c, configs)
+		if err != nil {
+			r.report(err)
+			status = statusFail
+		}
+		if status != statusOK {
+			ok = false
+		}
+		if len(pkgs) > 1 {
+			fmt.Fprintf(os.Stderr, "%s\t%s\n", status, pkg.PkgPath)
+		}
 	}
-	if err = wrapper.Generate(fs, pkg, options...); err != nil {
-		log.Fatal("err", err)
+
+	if len(pkgs) > 1 {
+		for _, typ := range types {
+			if !found[typ] {
+				r.report(&wrapper.Diagnostic{Code: wrapper.CodeMissingType, Message: fmt.Sprintf("struct type %s not found in any package", typ)})
+				ok = false
+			}
+		}
 	}
+
+	return ok
 }
 
-func isDir(name string) bool {
-	info, err := os.Stat(name)
+// generate generates the wrappers of types in pkg, or checks or previews them if -check
+// or -dry-run is set, and returns the status of pkg.
+func generate(fs afero.Fs, pkg *wrapper.Package, types []string, c *config, configs *configLoader) (string, error) {
+	if pkg.Dir == "" && len(pkg.Errors) > 0 {
+		return "", errors.New(pkg.Errors[0].Msg)
+	}
+
+	cf, err := configs.load(pkg.Dir)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+	options := append(packageOptions(cf, pkg, c.explicit), wrapper.All(c.all), wrapper.Types(types...))
+
+	switch {
+	case c.check:
+		diff, err := wrapper.Check(fs, pkg, options...)
+		if err != nil {
+			return "", err
+		}
+		if diff != "" {
+			fmt.Fprint(os.Stdout, diff)
+			return statusStale, nil
+		}
+	case c.dryRun:
+		changes, err := wrapper.DryRun(fs, pkg, options...)
+		if err != nil {
+			return "", err
+		}
+		for _, change := range changes {
+			fmt.Fprintf(os.Stdout, "%s %s (%d methods)\n", change.Status, change.Path, change.Methods)
+		}
+	default:
+		if err := wrapper.Generate(fs, pkg, options...); err != nil {
+			return "", err
+		}
+	}
+
+	return statusOK, nil
+}
+
+// splitTypeNames splits a comma-separated list of type names.
+func splitTypeNames(typeNames string) []string {
+	var types []string
+	for _, name := range strings.Split(typeNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			types = append(types, name)
+		}
 	}
-	return info.IsDir()
+	return types
 }
 
 func getVersion() string {
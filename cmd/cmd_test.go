@@ -1,7 +1,19 @@
 package cmd_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -30,6 +42,22 @@ func TestExecute(t *testing.T) {
 			cmd:    "type-wrapper -type Tester -interface ITester -reader testdata/getter",
 			output: "testdata/getter/tester_wrapper.go",
 		},
+		"GetterAndReaderAndWriter": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester -reader -writer testdata/getter",
+			output: "testdata/getter/tester_wrapper.go",
+		},
+		"XMLCodec": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester -reader -writer -codec xml testdata/getter",
+			output: "testdata/getter/tester_wrapper.go",
+		},
+		"GobCodec": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester -reader -writer -codec gob testdata/getter",
+			output: "testdata/getter/tester_wrapper.go",
+		},
+		"CustomCodec": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester -reader -writer -codec gopkg.in/yaml.v3.Marshal testdata/getter",
+			output: "testdata/getter/tester_wrapper.go",
+		},
 		"Setter": {
 			cmd:    "type-wrapper -type Tester testdata/setter",
 			output: "testdata/setter/tester_wrapper.go",
@@ -46,6 +74,10 @@ func TestExecute(t *testing.T) {
 			cmd:    "type-wrapper -type Tester testdata/import_packages",
 			output: "testdata/import_packages/tester_wrapper.go",
 		},
+		"ImportCollisions": {
+			cmd:    "type-wrapper -type Tester,OtherTester -pointer -reader -interface I{{.Type}} -output wrappers.go testdata/import_collisions",
+			output: "testdata/import_collisions/wrappers.go",
+		},
 		"WithOutput": {
 			cmd:    "type-wrapper -type Tester -output my_wrapper.go testdata/with_output",
 			output: "testdata/with_output/my_wrapper.go",
@@ -59,13 +91,145 @@ func TestExecute(t *testing.T) {
 			output: "testdata/with_receiver/tester_wrapper.go",
 		},
 		"WithLock": {
-			cmd:    "type-wrapper -type Tester -lock lock testdata/with_lock",
+			cmd:    "type-wrapper -type Tester -lock lock -pointer testdata/with_lock",
 			output: "testdata/with_lock/tester_wrapper.go",
 		},
 		"WithLockAndInterfaceAndReader": {
-			cmd:    "type-wrapper -type Tester -lock lock -interface ITester -reader testdata/with_lock",
+			cmd:    "type-wrapper -type Tester -lock lock -pointer -interface ITester -reader testdata/with_lock",
 			output: "testdata/with_lock/tester_wrapper.go",
 		},
+		"WithRWLock": {
+			cmd:    "type-wrapper -type Tester -lock lock -pointer -interface ITester -reader testdata/with_rwlock",
+			output: "testdata/with_rwlock/tester_wrapper.go",
+		},
+		"WithFieldLocks": {
+			cmd:    "type-wrapper -type Tester -lock lock -pointer -reader testdata/with_field_locks",
+			output: "testdata/with_field_locks/tester_wrapper.go",
+		},
+		"WithAtomic": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester testdata/with_atomic",
+			output: "testdata/with_atomic/tester_wrapper.go",
+		},
+		"WithLockAndWriter": {
+			cmd:    "type-wrapper -type Tester -lock lock -pointer -writer testdata/with_field_locks",
+			output: "testdata/with_field_locks/tester_wrapper.go",
+		},
+		"Pointer": {
+			cmd:    "type-wrapper -type Tester -pointer testdata/getter_and_setter",
+			output: "testdata/getter_and_setter/tester_wrapper.go",
+		},
+		"PointerAndInterface": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester testdata/getter_and_setter",
+			output: "testdata/getter_and_setter/tester_wrapper.go",
+		},
+		"MultipleTypes": {
+			cmd:    "type-wrapper -type Tester,OtherTester -interface I{{.Type}} testdata/multiple_types",
+			output: "testdata/multiple_types/other_tester_wrapper.go",
+		},
+		"MultipleTypesWithOutput": {
+			cmd:    "type-wrapper -type Tester,OtherTester -wrapper Wrapped{{.Type}} -output wrappers.go testdata/multiple_types",
+			output: "testdata/multiple_types/wrappers.go",
+		},
+		"AllTypes": {
+			cmd:    "type-wrapper -all -pointer -interface I{{.Type}} testdata/multiple_types",
+			output: "testdata/multiple_types/tester_wrapper.go",
+		},
+		"Recursive": {
+			cmd:    "type-wrapper -all -interface I{{.Type}} testdata/recursive/...",
+			output: "testdata/recursive/nested/other_tester_wrapper.go",
+		},
+		"SeveralPackages": {
+			cmd:    "type-wrapper -type Tester,OtherTester -wrapper Wrapped{{.Type}} testdata/recursive testdata/recursive/nested",
+			output: "testdata/recursive/nested/tester_wrapper.go",
+		},
+		"GenerateDirectives": {
+			cmd:    "type-wrapper generate testdata/directives/...",
+			output: "testdata/directives/tester_wrapper.go",
+		},
+		"GenerateDirectivesGoRun": {
+			cmd:    "type-wrapper generate testdata/directives/...",
+			output: "testdata/directives/other_tester.go",
+		},
+		"GenerateDirectivesQuoted": {
+			cmd:    "type-wrapper generate testdata/directives/...",
+			output: "testdata/directives/sub/tester_wrapper.go",
+		},
+		"WithConfig": {
+			cmd:    "type-wrapper -all testdata/with_config/...",
+			output: "testdata/with_config/tester_wrapper.go",
+		},
+		"WithConfigTypeInPackage": {
+			cmd:    "type-wrapper -all testdata/with_config/...",
+			output: "testdata/with_config/nested/other_tester_wrapper.go",
+		},
+		"WithConfigAndFlags": {
+			cmd:    "type-wrapper -type Tester -receiver tester -reader=false testdata/with_config",
+			output: "testdata/with_config/tester_wrapper.go",
+		},
+		"CustomTemplates": {
+			cmd:    "type-wrapper -type Tester -interface ITester -templates testdata/custom_templates testdata/getter",
+			output: "testdata/getter/tester_wrapper.go",
+		},
+		"CollisionsSkip": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester -collisions skip testdata/collisions",
+			output: "testdata/collisions/tester_wrapper.go",
+		},
+		"CollisionsSkipForeignType": {
+			cmd:    "type-wrapper -type Timed -pointer -interface ITimed -collisions skip testdata/collisions",
+			output: "testdata/collisions/timed_wrapper.go",
+		},
+		"CollisionsRename": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester -collisions rename testdata/collisions",
+			output: "testdata/collisions/tester_wrapper.go",
+		},
+		"EmbeddedStructs": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester testdata/embedded",
+			output: "testdata/embedded/tester_wrapper.go",
+		},
+		"EmbeddedPointers": {
+			cmd:    "type-wrapper -type Tester -pointer -embedded-pointers -interface ITester testdata/embedded",
+			output: "testdata/embedded/tester_wrapper.go",
+		},
+		"DefensiveCopy": {
+			cmd:    "type-wrapper -type Tester -pointer -defensive-copy -interface ITester testdata/defensive_copy",
+			output: "testdata/defensive_copy/tester_wrapper.go",
+		},
+		"AtomicClone": {
+			cmd:    "type-wrapper -type Tester -pointer -defensive-copy testdata/atomic_clone",
+			output: "testdata/atomic_clone/tester_wrapper.go",
+		},
+		"CopyTag": {
+			cmd:    "type-wrapper -type Tagged -pointer testdata/defensive_copy",
+			output: "testdata/defensive_copy/tagged_wrapper.go",
+		},
+		"ChangeHooks": {
+			cmd:    "type-wrapper -type Tester -pointer -lock mu -change-hooks -interface ITester testdata/change_hooks",
+			output: "testdata/change_hooks/tester_wrapper.go",
+		},
+		"TrackChanges": {
+			cmd:    "type-wrapper -type Tester -pointer -embedded-pointers -changed-json -change-hooks -interface ITester testdata/track_changes",
+			output: "testdata/track_changes/tester_wrapper.go",
+		},
+		"TrackChangesSkippedSetter": {
+			cmd:    "type-wrapper -type Counter -pointer -changed-json -collisions skip testdata/track_skipped_setter",
+			output: "testdata/track_skipped_setter/counter_wrapper.go",
+		},
+		"ForeignType": {
+			cmd:    "type-wrapper -type github.com/Marble-Technologies/type-wrapper/cmd/testdata/foreign/lib.Config -pointer -interface IConfig -reader -writer testdata/foreign",
+			output: "testdata/foreign/config_wrapper.go",
+		},
+		"Generics": {
+			cmd:    "type-wrapper -type Tester -pointer -interface ITester -reader testdata/generics",
+			output: "testdata/generics/tester_wrapper.go",
+		},
+		"Registry": {
+			cmd:    "type-wrapper -all -pointer -interface I{{.Type}} -reader -writer -registry testdata/multiple_types",
+			output: "testdata/multiple_types/type_wrapper_registry.go",
+		},
+		"RegistryWrapper": {
+			cmd:    "type-wrapper -all -pointer -interface I{{.Type}} -reader -writer -registry testdata/multiple_types",
+			output: "testdata/multiple_types/other_tester_wrapper.go",
+		},
 	}
 
 	fs := afero.NewMemMapFs()
@@ -80,7 +244,9 @@ func TestExecute(t *testing.T) {
 			//	t.Parallel()
 
 			args := strings.Split(tt.cmd, " ")
-			cmd.Execute(fs, args)
+			if err := cmd.Execute(fs, args); err != nil {
+				t.Fatal(err)
+			}
 
 			output, _ := filepath.Abs(tt.output)
 
@@ -101,3 +267,477 @@ func TestExecute(t *testing.T) {
 		})
 	}
 }
+
+// TestGeneratedCode generates wrappers in a copy of a testdata package and runs a
+// test of the package, which exercises the generated code.
+func TestGeneratedCode(t *testing.T) {
+	t.Parallel()
+
+	if testing.Short() {
+		t.Skip("builds the generated code")
+	}
+
+	tests := map[string]struct {
+		cmd    string
+		output string
+		run    string // test of the package to run
+		race   bool   // run the test with the race detector
+		copies bool   // the generated code copies values with slices or maps, which need Go 1.21
+	}{
+		"ChunkedXMLWrite": {
+			cmd:    "type-wrapper -type Tester -pointer -reader -writer -codec xml testdata/runtime",
+			output: "testdata/runtime/tester_wrapper.go",
+			run:    "TestChunkedWrite",
+		},
+		"ChunkedGobWrite": {
+			cmd:    "type-wrapper -type Tester -pointer -reader -writer -codec gob testdata/runtime",
+			output: "testdata/runtime/tester_wrapper.go",
+			run:    "TestChunkedWrite",
+		},
+		"ChunkedJSONWrite": {
+			cmd:    "type-wrapper -type Tester -pointer -reader -writer testdata/runtime",
+			output: "testdata/runtime/tester_wrapper.go",
+			run:    "TestChunkedWrite",
+		},
+		"JSONWriteErrors": {
+			cmd:    "type-wrapper -type Tester -pointer -reader -writer testdata/runtime",
+			output: "testdata/runtime/tester_wrapper.go",
+			run:    "TestWriteErrors",
+		},
+		"DeepClone": {
+			cmd:    "type-wrapper -type Tester -pointer -defensive-copy testdata/defensive_copy",
+			output: "testdata/defensive_copy/tester_wrapper.go",
+			run:    "TestDeepClone",
+			copies: true,
+		},
+		"AtomicClone": {
+			cmd:    "type-wrapper -type Tester -pointer -defensive-copy testdata/atomic_clone",
+			output: "testdata/atomic_clone/tester_wrapper.go",
+			run:    "TestCloneRace",
+			race:   true,
+			copies: true,
+		},
+		"ChangedJSON": {
+			cmd:    "type-wrapper -type Tester -pointer -changed-json testdata/track_changes",
+			output: "testdata/track_changes/tester_wrapper.go",
+			run:    "TestChangedJSON",
+		},
+		"SkippedSetterChanges": {
+			cmd:    "type-wrapper -type Counter -pointer -changed-json -collisions skip testdata/track_skipped_setter",
+			output: "testdata/track_skipped_setter/counter_wrapper.go",
+			run:    "TestSkippedSetter",
+		},
+		"AtomicHooks": {
+			cmd:    "type-wrapper -type Tester -pointer -lock mu -change-hooks testdata/change_hooks",
+			output: "testdata/change_hooks/tester_wrapper.go",
+			run:    "TestAtomicHooks",
+			race:   true,
+			copies: true,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if tt.copies && !hasReleaseTag("go1.21") {
+				t.Skip("the generated code needs Go 1.21")
+			}
+
+			fs := afero.NewMemMapFs()
+			if err := cmd.Execute(fs, strings.Split(tt.cmd, " ")); err != nil {
+				t.Fatal(err)
+			}
+			output, _ := filepath.Abs(tt.output)
+			generated, err := afero.ReadFile(fs, output)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dir := t.TempDir()
+			src := filepath.Dir(output)
+			entries, err := os.ReadDir(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, entry := range entries {
+				content, err := os.ReadFile(filepath.Join(src, entry.Name()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, entry.Name()), content, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.WriteFile(filepath.Join(dir, filepath.Base(output)), generated, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module test\n\ngo 1.21\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			args := []string{"test", "-run=^" + tt.run + "$"}
+			if tt.race {
+				if out, err := exec.Command("go", "env", "CGO_ENABLED").Output(); err != nil || strings.TrimSpace(string(out)) != "1" {
+					t.Skip("the race detector requires cgo")
+				}
+				args = append(args, "-race")
+			}
+			c := exec.Command("go", append(args, ".")...)
+			c.Dir = dir
+			if out, err := c.CombinedOutput(); err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+		})
+	}
+}
+
+// subprocessEnv is set to the args of Execute when a test runs it in a subprocess,
+// because Execute writes to stdout.
+const subprocessEnv = "TYPE_WRAPPER_EXECUTE_ARGS"
+
+// executeInSubprocess runs Execute with args and a memory file system in a
+// subprocess running test, and returns its stdout. The subprocess exits with
+// status 1 if Execute fails.
+func executeInSubprocess(t *testing.T, test, args string) ([]byte, error) {
+	t.Helper()
+
+	c := exec.Command(os.Args[0], "-test.run=^"+test+"$")
+	c.Env = append(os.Environ(), subprocessEnv+"="+args)
+	return c.Output()
+}
+
+// executeSubprocess runs Execute if the test runs in a subprocess, and reports whether it did.
+func executeSubprocess() bool {
+	args := os.Getenv(subprocessEnv)
+	if args == "" {
+		return false
+	}
+	if err := cmd.Execute(afero.NewMemMapFs(), strings.Split(args, " ")); err != nil {
+		os.Exit(1)
+	}
+	return true
+}
+
+func TestExecuteCheck(t *testing.T) {
+	t.Parallel()
+
+	const (
+		args      = "type-wrapper -type Tester -interface ITester -reader testdata/getter"
+		checkArgs = "type-wrapper -check -type Tester -interface ITester -reader testdata/getter"
+	)
+
+	if executeSubprocess() {
+		return
+	}
+
+	fs := afero.NewMemMapFs()
+	if err := cmd.Execute(fs, strings.Split(args, " ")); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Execute(fs, strings.Split(checkArgs, " ")); err != nil {
+		t.Fatalf("check of up-to-date wrappers: %v", err)
+	}
+
+	// an empty file system holds no wrappers, so they are all stale
+	out, err := executeInSubprocess(t, "TestExecuteCheck", checkArgs)
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("check of stale wrappers: want exit status 1, got %v", err)
+	}
+	if !strings.Contains(string(out), "+func (t TesterWrapper) Field1() string {") {
+		t.Fatalf("check of stale wrappers: diff does not add Field1 getter:\n%s", out)
+	}
+}
+
+func TestExecuteStdout(t *testing.T) {
+	t.Parallel()
+
+	if executeSubprocess() {
+		return
+	}
+
+	snapshot := cupaloy.New(
+		cupaloy.SnapshotSubdirectory("testdata/.snapshots"),
+		cupaloy.SnapshotFileExtension(".go"),
+	)
+
+	out, err := executeInSubprocess(t, "TestExecuteStdout",
+		"type-wrapper -output - -type Tester,OtherTester -wrapper Wrapped{{.Type}} -pointer -reader -registry testdata/multiple_types")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out = bytes.TrimSuffix(out, []byte("PASS\n"))
+
+	snapshot.SnapshotT(t, out)
+}
+
+func TestExecuteDryRun(t *testing.T) {
+	t.Parallel()
+
+	if executeSubprocess() {
+		return
+	}
+
+	out, err := executeInSubprocess(t, "TestExecuteDryRun",
+		"type-wrapper -dry-run -all -pointer -interface I{{.Type}} -reader -registry testdata/multiple_types")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, _ := filepath.Abs("testdata/multiple_types")
+	for _, want := range []string{
+		"create " + filepath.Join(dir, "other_tester_wrapper.go") + " (6 methods)\n",
+		"create " + filepath.Join(dir, "tester_wrapper.go") + " (7 methods)\n",
+		"create " + filepath.Join(dir, "type_wrapper_registry.go") + " (0 methods)\n",
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("dry run: output does not contain %q:\n%s", want, out)
+		}
+	}
+
+	out, err = executeInSubprocess(t, "TestExecuteDryRun", "type-wrapper -dry-run -all testdata/recursive/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, _ = filepath.Abs("testdata/recursive")
+	want := "create " + filepath.Join(dir, "tester_wrapper.go") + " (2 methods)\n" +
+		"create " + filepath.Join(dir, "nested", "other_tester_wrapper.go") + " (2 methods)\n" +
+		"create " + filepath.Join(dir, "nested", "tester_wrapper.go") + " (1 methods)\n"
+	if got := string(bytes.TrimSuffix(out, []byte("PASS\n"))); got != want {
+		t.Errorf("dry run of several packages: want\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestExecuteConfigPrint(t *testing.T) {
+	t.Parallel()
+
+	if executeSubprocess() {
+		return
+	}
+
+	out, err := executeInSubprocess(t, "TestExecuteConfigPrint",
+		"type-wrapper config print -type OtherTester -pointer=false testdata/with_config/nested")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path, _ := filepath.Abs("testdata/with_config/.type-wrapper.yaml")
+	want := "# github.com/Marble-Technologies/type-wrapper/cmd/testdata/with_config/nested.OtherTester (" + path + ")\n" +
+		`reader: false
+writer: false
+registry: false
+codec: json
+codec-tag: ""
+wrapper: Guarded{{.Type}}
+interface: I{{.Type}}
+lock: mu
+receiver: s
+pointer: false
+embedded-pointers: false
+defensive-copy: false
+change-hooks: false
+track-changes: false
+changed-json: false
+output: ""
+collisions: error
+templates: ""
+`
+	if got := string(bytes.TrimSuffix(out, []byte("PASS\n"))); got != want {
+		t.Errorf("config print: want\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestExecuteDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		cmd     string
+		want    []string // base name of the file, line, column and code of each diagnostic
+		aliases bool     // the problem is only found if go/types keeps aliases
+	}{
+		"MissingType": {
+			cmd:  "type-wrapper -type Missing,Other testdata/diagnostics",
+			want: []string{"tester.go:1:9 missing-type", "tester.go:1:9 missing-type"},
+		},
+		"MalformedTag": {
+			cmd:  "type-wrapper -type MalformedTag testdata/diagnostics",
+			want: []string{"tester.go:10:2 malformed-tag", "tester.go:11:2 malformed-tag", "tester.go:12:2 malformed-tag"},
+		},
+		"MethodCollision": {
+			cmd:  "type-wrapper -type Collision -reader testdata/diagnostics",
+			want: []string{"tester.go:17:2 method-collision", "tester.go:18:2 method-collision"},
+		},
+		"GeneratedFieldCollision": {
+			cmd:  "type-wrapper -type FieldCollision -pointer -reader -change-hooks testdata/diagnostics",
+			want: []string{"tester.go:44:2 method-collision", "tester.go:46:2 method-collision"},
+		},
+		"ShadowedMethod": {
+			cmd:  "type-wrapper -type Tester -pointer testdata/collisions",
+			want: []string{"tester.go:10:2 method-collision", "tester.go:11:2 method-collision", "tester.go:12:2 method-collision"},
+		},
+		"UnexportedType": {
+			cmd:  "type-wrapper -type github.com/Marble-Technologies/type-wrapper/cmd/testdata/foreign/lib.Report testdata/foreign",
+			want: []string{"lib.go:29:2 unexported-type", "lib.go:30:2 unexported-type"},
+		},
+		"UnexportedAlias": {
+			cmd:     "type-wrapper -type github.com/Marble-Technologies/type-wrapper/cmd/testdata/foreign/lib.Labeled testdata/foreign",
+			want:    []string{"lib.go:39:2 unexported-type"},
+			aliases: true,
+		},
+		"InvalidLock": {
+			cmd:  "type-wrapper -type InvalidLock testdata/diagnostics",
+			want: []string{"tester.go:28:2 invalid-lock", "tester.go:28:2 invalid-lock", "tester.go:27:2 invalid-lock"},
+		},
+		"ChangeHooksValueReceivers": {
+			cmd:  "type-wrapper -type Tester -change-hooks testdata/change_hooks",
+			want: []string{"tester.go:9:6 error"},
+		},
+		"TrackChangesValueReceivers": {
+			cmd:  "type-wrapper -type Tester -track-changes testdata/track_changes",
+			want: []string{"tester.go:12:6 error", "tester.go:14:2 invalid-lock"},
+		},
+		"ReceiverClashesWithLocal": {
+			cmd:  "type-wrapper -type Collision -receiver data -reader testdata/diagnostics",
+			want: []string{"tester.go:15:6 invalid-receiver", "tester.go:17:2 method-collision", "tester.go:18:2 method-collision"},
+		},
+		"ReceiverClashesWithPackage": {
+			cmd:  "type-wrapper -type MalformedTag -receiver json testdata/diagnostics",
+			want: []string{"tester.go:10:2 malformed-tag", "tester.go:11:2 malformed-tag", "tester.go:12:2 malformed-tag", "tester.go:9:6 invalid-receiver"},
+		},
+		"ReceiverClashesWithIOParameter": {
+			cmd:  "type-wrapper -type MalformedTag -receiver p -reader testdata/diagnostics",
+			want: []string{"tester.go:10:2 malformed-tag", "tester.go:11:2 malformed-tag", "tester.go:12:2 malformed-tag", "tester.go:9:6 invalid-receiver"},
+		},
+		"UnexportedField": {
+			cmd:  "type-wrapper -type github.com/Marble-Technologies/type-wrapper/cmd/testdata/foreign/lib.Session -pointer -lock mu testdata/foreign",
+			want: []string{"lib.go:24:2 unexported-field", "lib.go:21:6 invalid-lock"},
+		},
+		"UnexportedPromotedField": {
+			cmd:  "type-wrapper -type UnexportedPromotedField testdata/diagnostics",
+			want: []string{"tester.go:34:9 unexported-field"},
+		},
+		"MalformedTagAmongOtherKeys": {
+			cmd:  "type-wrapper -type OtherTagKey testdata/diagnostics",
+			want: []string{"tester.go:40:2 malformed-tag"},
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if tt.aliases && typesResolveAliases(t) {
+				t.Skip("go/types resolves aliases to the types they denote")
+			}
+
+			fs := afero.NewMemMapFs()
+			err := cmd.Execute(fs, strings.Split(tt.cmd, " "))
+			var diagnostics cmd.Diagnostics
+			if !errors.As(err, &diagnostics) {
+				t.Fatalf("want diagnostics, got %v", err)
+			}
+
+			got := make([]string, len(diagnostics))
+			for i, d := range diagnostics {
+				got[i] = fmt.Sprintf("%s:%d:%d %s", filepath.Base(d.File), d.Line, d.Column, d.Code)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("want diagnostics\n%s\ngot\n%s", strings.Join(tt.want, "\n"), err)
+			}
+
+			if files, _ := afero.ReadDir(fs, "testdata/diagnostics"); len(files) > 0 {
+				t.Errorf("wrappers generated despite diagnostics")
+			}
+		})
+	}
+}
+
+// TestExecuteExportedAlias tests that a field whose type is an exported alias of an
+// unexported type is written with the alias, when go/types keeps aliases.
+func TestExecuteExportedAlias(t *testing.T) {
+	t.Parallel()
+
+	if typesResolveAliases(t) {
+		t.Skip("go/types resolves aliases to the unexported types they denote")
+	}
+
+	fs := afero.NewMemMapFs()
+	if err := cmd.Execute(fs, strings.Split("type-wrapper -type UnexportedType testdata/diagnostics", " ")); err != nil {
+		t.Fatal(err)
+	}
+	output, _ := filepath.Abs("testdata/diagnostics/unexported_type_wrapper.go")
+	file, err := afero.ReadFile(fs, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"github.com/Marble-Technologies/type-wrapper/cmd/testdata/diagnostics/hidden"`,
+		"Field1() hidden.Public",
+		"SetField2(val []hidden.Public)",
+	} {
+		if !bytes.Contains(file, []byte(want)) {
+			t.Errorf("want %s in\n%s", want, file)
+		}
+	}
+}
+
+// typesResolveAliases reports whether go/types resolves aliases to the types they
+// denote, rather than keeping them as types.Alias since Go 1.23.
+func typesResolveAliases(t *testing.T) bool {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "alias.go", "package alias; type A = struct{}; var V A", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := new(types.Config).Check("alias", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg.Scope().Lookup("V").Type().String() == "struct{}"
+}
+
+// hasReleaseTag reports whether the Go release running the tests has the release
+// tag tag, such as go1.21.
+func hasReleaseTag(tag string) bool {
+	for _, t := range build.Default.ReleaseTags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExecuteDiagnosticsJSON(t *testing.T) {
+	t.Parallel()
+
+	if executeSubprocess() {
+		return
+	}
+
+	_, err := executeInSubprocess(t, "TestExecuteDiagnosticsJSON",
+		"type-wrapper -format json -type Collision,Missing testdata/diagnostics")
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("want exit status 1, got %v", err)
+	}
+
+	var diagnostics []cmd.Diagnostic
+	if err := json.Unmarshal(exitErr.Stderr, &diagnostics); err != nil {
+		t.Fatalf("stderr is not a JSON array of diagnostics: %v\n%s", err, exitErr.Stderr)
+	}
+	path, _ := filepath.Abs("testdata/diagnostics/tester.go")
+	want := []cmd.Diagnostic{{
+		File:    path,
+		Line:    1,
+		Column:  9,
+		Code:    "missing-type",
+		Message: "struct type Missing not found in package diagnostics",
+	}}
+	if !reflect.DeepEqual(diagnostics, want) {
+		t.Errorf("want %+v, got %+v", want, diagnostics)
+	}
+}
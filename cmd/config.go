@@ -0,0 +1,452 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Marble-Technologies/type-wrapper/wrapper"
+)
+
+// configFileNames are the names of the configuration file, which is looked up
+// from the directory of a package upward.
+var configFileNames = []string{".type-wrapper.yaml", ".type-wrapper.yml"}
+
+// settings are the options of the generator set by a configuration file or by
+// flags; nil fields are not set.
+type settings struct {
+	Reader     *bool   `yaml:"reader,omitempty"`
+	Writer     *bool   `yaml:"writer,omitempty"`
+	Registry   *bool   `yaml:"registry,omitempty"`
+	Codec      *string `yaml:"codec,omitempty"`
+	CodecTag   *string `yaml:"codec-tag,omitempty"`
+	Wrapper    *string `yaml:"wrapper,omitempty"`
+	Interface  *string `yaml:"interface,omitempty"`
+	Lock       *string `yaml:"lock,omitempty"`
+	Receiver   *string `yaml:"receiver,omitempty"`
+	Pointer    *bool   `yaml:"pointer,omitempty"`
+	Embedded   *bool   `yaml:"embedded-pointers,omitempty"`
+	Copy       *bool   `yaml:"defensive-copy,omitempty"`
+	Hooks      *bool   `yaml:"change-hooks,omitempty"`
+	Track      *bool   `yaml:"track-changes,omitempty"`
+	JSON       *bool   `yaml:"changed-json,omitempty"`
+	Output     *string `yaml:"output,omitempty"`
+	Collisions *string `yaml:"collisions,omitempty"`
+	Templates  *string `yaml:"templates,omitempty"`
+}
+
+// merge returns s with the fields set in o overriding it.
+func (s settings) merge(o *settings) settings {
+	if o == nil {
+		return s
+	}
+	if o.Reader != nil {
+		s.Reader = o.Reader
+	}
+	if o.Writer != nil {
+		s.Writer = o.Writer
+	}
+	if o.Registry != nil {
+		s.Registry = o.Registry
+	}
+	if o.Codec != nil {
+		s.Codec = o.Codec
+	}
+	if o.CodecTag != nil {
+		s.CodecTag = o.CodecTag
+	}
+	if o.Wrapper != nil {
+		s.Wrapper = o.Wrapper
+	}
+	if o.Interface != nil {
+		s.Interface = o.Interface
+	}
+	if o.Lock != nil {
+		s.Lock = o.Lock
+	}
+	if o.Receiver != nil {
+		s.Receiver = o.Receiver
+	}
+	if o.Pointer != nil {
+		s.Pointer = o.Pointer
+	}
+	if o.Embedded != nil {
+		s.Embedded = o.Embedded
+	}
+	if o.Copy != nil {
+		s.Copy = o.Copy
+	}
+	if o.Hooks != nil {
+		s.Hooks = o.Hooks
+	}
+	if o.Track != nil {
+		s.Track = o.Track
+	}
+	if o.JSON != nil {
+		s.JSON = o.JSON
+	}
+	if o.Output != nil {
+		s.Output = o.Output
+	}
+	if o.Collisions != nil {
+		s.Collisions = o.Collisions
+	}
+	if o.Templates != nil {
+		s.Templates = o.Templates
+	}
+	return s
+}
+
+// options returns the options of Generate set by s.
+func (s *settings) options() []wrapper.Option {
+	var options []wrapper.Option
+	if s.Reader != nil {
+		options = append(options, wrapper.Reader(*s.Reader))
+	}
+	if s.Writer != nil {
+		options = append(options, wrapper.Writer(*s.Writer))
+	}
+	if s.Registry != nil {
+		options = append(options, wrapper.Registry(*s.Registry))
+	}
+	if s.Codec != nil {
+		options = append(options, wrapper.Codec(*s.Codec))
+	}
+	if s.CodecTag != nil {
+		options = append(options, wrapper.CodecTag(*s.CodecTag))
+	}
+	if s.Wrapper != nil {
+		options = append(options, wrapper.Wrapper(*s.Wrapper))
+	}
+	if s.Interface != nil {
+		options = append(options, wrapper.Interface(*s.Interface))
+	}
+	if s.Lock != nil {
+		options = append(options, wrapper.Lock(*s.Lock))
+	}
+	if s.Receiver != nil {
+		options = append(options, wrapper.Receiver(*s.Receiver))
+	}
+	if s.Pointer != nil {
+		options = append(options, wrapper.Pointer(*s.Pointer))
+	}
+	if s.Embedded != nil {
+		options = append(options, wrapper.EmbeddedPointers(*s.Embedded))
+	}
+	if s.Copy != nil {
+		options = append(options, wrapper.DefensiveCopy(*s.Copy))
+	}
+	if s.Hooks != nil {
+		options = append(options, wrapper.ChangeHooks(*s.Hooks))
+	}
+	if s.Track != nil {
+		options = append(options, wrapper.TrackChanges(*s.Track))
+	}
+	if s.JSON != nil {
+		options = append(options, wrapper.ChangedJSON(*s.JSON))
+	}
+	if s.Output != nil {
+		options = append(options, wrapper.Output(*s.Output))
+	}
+	if s.Collisions != nil {
+		options = append(options, wrapper.Collisions(*s.Collisions))
+	}
+	if s.Templates != nil {
+		options = append(options, wrapper.Templates(*s.Templates))
+	}
+	return options
+}
+
+// flagSettings returns the settings of the flags in flags, which are parsed into c.
+// Only the flags set explicitly are set unless all is set.
+func flagSettings(flags *flag.FlagSet, c *config, all bool) *settings {
+	s := new(settings)
+	visit := func(f *flag.Flag) {
+		switch f.Name {
+		case "reader":
+			s.Reader = &c.reader
+		case "writer":
+			s.Writer = &c.writer
+		case "registry":
+			s.Registry = &c.registry
+		case "codec":
+			s.Codec = &c.codec
+		case "codec-tag":
+			s.CodecTag = &c.codecTag
+		case "wrapper":
+			s.Wrapper = &c.wrapperTypeName
+		case "interface":
+			s.Interface = &c.interfaceName
+		case "lock":
+			s.Lock = &c.lockName
+		case "receiver":
+			s.Receiver = &c.receiver
+		case "pointer":
+			s.Pointer = &c.pointer
+		case "embedded-pointers":
+			s.Embedded = &c.embeddedPtrs
+		case "defensive-copy":
+			s.Copy = &c.defensiveCopy
+		case "change-hooks":
+			s.Hooks = &c.changeHooks
+		case "track-changes":
+			s.Track = &c.trackChanges
+		case "changed-json":
+			s.JSON = &c.changedJSON
+		case "output":
+			s.Output = &c.output
+		case "collisions":
+			s.Collisions = &c.collisions
+		case "templates":
+			s.Templates = &c.templates
+		}
+	}
+	if all {
+		flags.VisitAll(visit)
+	} else {
+		flags.Visit(visit)
+	}
+	return s
+}
+
+// packageConfig holds the settings of the packages or of a single package,
+// and the settings of their types.
+type packageConfig struct {
+	settings `yaml:",inline"`
+	Types    map[string]*settings `yaml:"types,omitempty"`
+}
+
+// configFile is a configuration file. Its settings are the defaults of every
+// package, and packages are keyed by directory relative to the file or by import path.
+type configFile struct {
+	packageConfig `yaml:",inline"`
+	Packages      map[string]*packageConfig `yaml:"packages,omitempty"`
+
+	path string
+}
+
+// readConfigFile reads the configuration file at path.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cf := &configFile{path: path}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cf); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// template directories are relative to the file
+	dir := filepath.Dir(path)
+	cf.packageConfig.resolvePaths(dir)
+	for _, pc := range cf.Packages {
+		pc.resolvePaths(dir)
+	}
+
+	return cf, nil
+}
+
+// resolvePaths makes the relative paths of pc relative to dir.
+func (pc *packageConfig) resolvePaths(dir string) {
+	if pc == nil {
+		return
+	}
+	pc.settings.resolvePaths(dir)
+	for _, s := range pc.Types {
+		s.resolvePaths(dir)
+	}
+}
+
+// resolvePaths makes the relative paths of s relative to dir.
+func (s *settings) resolvePaths(dir string) {
+	if s != nil && s.Templates != nil && *s.Templates != "" && !filepath.IsAbs(*s.Templates) {
+		templates := filepath.Join(dir, *s.Templates)
+		s.Templates = &templates
+	}
+}
+
+// lookupPackage returns the settings of pkg in cf, or nil if cf has none.
+func (cf *configFile) lookupPackage(pkg *wrapper.Package) *packageConfig {
+	dir := filepath.Dir(cf.path)
+	for key, pc := range cf.Packages {
+		if key == pkg.PkgPath || filepath.Join(dir, key) == pkg.Dir {
+			return pc
+		}
+	}
+	return nil
+}
+
+// resolve returns the settings of the type named typeName in pkg, or the settings
+// of pkg if typeName is empty. The most specific settings win: those of every
+// package, of pkg, of the type in every package, then of the type in pkg.
+func (cf *configFile) resolve(pkg *wrapper.Package, typeName string) settings {
+	if cf == nil {
+		return settings{}
+	}
+
+	pc := cf.lookupPackage(pkg)
+	s := cf.settings
+	if pc != nil {
+		s = s.merge(&pc.settings)
+	}
+	if typeName == "" {
+		return s
+	}
+
+	s = s.merge(cf.Types[typeName])
+	if pc != nil {
+		s = s.merge(pc.Types[typeName])
+	}
+	return s
+}
+
+// typeNames returns the sorted names of the types of pkg with settings in cf.
+func (cf *configFile) typeNames(pkg *wrapper.Package) []string {
+	if cf == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	add := func(types map[string]*settings) {
+		for name := range types {
+			if !seen[name] && pkg.HasStruct(name) {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	add(cf.Types)
+	if pc := cf.lookupPackage(pkg); pc != nil {
+		add(pc.Types)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// configLoader finds and reads the configuration files of packages, reading each file once.
+type configLoader struct {
+	files map[string]*configFile // by path
+}
+
+func newConfigLoader() *configLoader {
+	return &configLoader{files: make(map[string]*configFile)}
+}
+
+// load returns the configuration file found in dir or the nearest of its parents,
+// or nil if there is none.
+func (l *configLoader) load(dir string) (*configFile, error) {
+	for dir != "" {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if cf, ok := l.files[path]; ok {
+				return cf, nil
+			}
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+
+			cf, err := readConfigFile(path)
+			if err != nil {
+				return nil, err
+			}
+			l.files[path] = cf
+			return cf, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return nil, nil
+}
+
+// packageOptions returns the options of Generate for pkg: the settings of cf for pkg
+// and its types, overridden by the flags set explicitly.
+func packageOptions(cf *configFile, pkg *wrapper.Package, explicit *settings) []wrapper.Option {
+	s := cf.resolve(pkg, "").merge(explicit)
+	options := s.options()
+	for _, name := range cf.typeNames(pkg) {
+		ts := cf.resolve(pkg, name).merge(explicit)
+		options = append(options, wrapper.TypeOptions(name, ts.options()...))
+	}
+	return options
+}
+
+// printConfig prints the settings resolved for the type of the -type flag in
+// the packages matching the arguments, and returns the error of Execute.
+func printConfig(args []string) error {
+	flags, c := newFlagSet(commandName + " config print")
+	flags.Usage = newUsage(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	r, err := newReporter(c.format, os.Stderr)
+	if err != nil {
+		return usageError(flags, err)
+	}
+	types := c.types()
+	if len(types) != 1 {
+		return usageError(flags, errors.New("config print needs the name of a single type in -type"))
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		// Default: process whole package in current directory.
+		patterns = []string{"."}
+	}
+	pkgs, err := wrapper.ParsePackages(patterns...)
+	if err != nil {
+		r.report(err)
+		return r.result(false)
+	}
+
+	defaultFlags, defaults := newFlagSet(commandName)
+	explicit := flagSettings(flags, c, false)
+	configs := newConfigLoader()
+	found := false
+	for _, pkg := range pkgs {
+		if !pkg.HasStruct(types[0]) {
+			continue
+		}
+		found = true
+
+		cf, err := configs.load(pkg.Dir)
+		if err != nil {
+			r.report(err)
+			return r.result(false)
+		}
+		configured := cf.resolve(pkg, types[0])
+		s := flagSettings(defaultFlags, defaults, true).merge(&configured).merge(explicit)
+		out, err := yaml.Marshal(&s)
+		if err != nil {
+			r.report(err)
+			return r.result(false)
+		}
+
+		source := "no configuration file"
+		if cf != nil {
+			source = cf.path
+		}
+		fmt.Fprintf(os.Stdout, "# %s.%s (%s)\n%s", pkg.PkgPath, types[0], source, out)
+	}
+	if !found {
+		r.report(&wrapper.Diagnostic{Code: wrapper.CodeMissingType, Message: fmt.Sprintf("struct type %s not found in any package", types[0])})
+	}
+
+	return r.result(found)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+
+	"github.com/Marble-Technologies/type-wrapper/wrapper"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// ErrStale is returned by Execute when -check finds stale wrappers.
+var ErrStale = errors.New("wrappers are stale")
+
+// Diagnostic is a problem reported by Execute, at a position of the source if File is set.
+type Diagnostic struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (d *Diagnostic) Error() string {
+	pos := token.Position{Filename: d.File, Line: d.Line, Column: d.Column}
+	if !pos.IsValid() {
+		return d.Message
+	}
+	return pos.String() + ": " + d.Message
+}
+
+// Diagnostics is the error returned by Execute with the problems it reported.
+type Diagnostics []*Diagnostic
+
+func (ds Diagnostics) Error() string {
+	messages := make([]string, len(ds))
+	for i, d := range ds {
+		messages[i] = d.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// reporter reports the problems of a command in the format of the -format flag.
+// Problems are printed as they are found in the text format, and as a single
+// JSON array at the end of the command in the JSON format.
+type reporter struct {
+	format      string
+	out         io.Writer
+	diagnostics Diagnostics
+}
+
+func newReporter(format string, out io.Writer) (*reporter, error) {
+	if format != formatText && format != formatJSON {
+		return nil, fmt.Errorf("unknown format %q; use %s or %s", format, formatText, formatJSON)
+	}
+	return &reporter{format: format, out: out}, nil
+}
+
+// report reports the diagnostics of err.
+func (r *reporter) report(err error) {
+	for _, d := range wrapper.AsDiagnostics(err) {
+		diagnostic := &Diagnostic{
+			File:    d.Pos.Filename,
+			Line:    d.Pos.Line,
+			Column:  d.Pos.Column,
+			Code:    d.Code,
+			Message: d.Message,
+		}
+		r.diagnostics = append(r.diagnostics, diagnostic)
+		if r.format == formatText {
+			fmt.Fprintf(r.out, "%s: %s\n", commandName, diagnostic)
+		}
+	}
+}
+
+// reportAt reports err at pos.
+func (r *reporter) reportAt(pos token.Position, err error) {
+	r.report(&wrapper.Diagnostic{Pos: pos, Code: wrapper.CodeError, Message: err.Error()})
+}
+
+// result prints the JSON array of the diagnostics in the JSON format, and returns the
+// error of a command which reported whether it succeeded with ok: the diagnostics
+// if there are any, or ErrStale if the command did not succeed otherwise.
+func (r *reporter) result(ok bool) error {
+	if r.format == formatJSON {
+		diagnostics := r.diagnostics
+		if diagnostics == nil {
+			diagnostics = Diagnostics{}
+		}
+		enc := json.NewEncoder(r.out)
+		enc.SetIndent("", "\t")
+		if err := enc.Encode(diagnostics); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case len(r.diagnostics) > 0:
+		return r.diagnostics
+	case !ok:
+		return ErrStale
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/Marble-Technologies/type-wrapper/wrapper"
+)
+
+const (
+	commandName    = "type-wrapper"
+	generatePrefix = "//go:generate "
+)
+
+// directive is a go:generate directive running type-wrapper.
+type directive struct {
+	pos  token.Position
+	args []string // arguments of type-wrapper
+}
+
+// executeDirectives runs in process the go:generate directives running type-wrapper
+// in the packages matching the arguments, sharing the loaded packages between them.
+// It prints a summary line for each directive, and returns the error of Execute.
+func executeDirectives(fs afero.Fs, args []string) error {
+	flags, c := newFlagSet(commandName + " " + generateCommand)
+	flags.Usage = newUsage(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	r, err := newReporter(c.format, os.Stderr)
+	if err != nil {
+		return usageError(flags, err)
+	}
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		// Default: process whole package in current directory.
+		patterns = []string{"."}
+	}
+
+	pkgs, err := wrapper.ParsePackages(patterns...)
+	if err != nil {
+		r.report(err)
+		return r.result(false)
+	}
+
+	configs := newConfigLoader()
+	loaded := make(map[string]*wrapper.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		if pkg.Dir != "" {
+			loaded[pkg.Dir] = pkg
+		}
+	}
+
+	ok := true
+	for _, pkg := range pkgs {
+		directives, err := findDirectives(pkg)
+		if err != nil {
+			r.report(err)
+			ok = false
+			continue
+		}
+
+		for _, d := range directives {
+			status := statusOK
+			if !runDirective(fs, configs, loaded, pkg, d, r) {
+				status = statusFail
+				ok = false
+			}
+			fmt.Fprintf(os.Stderr, "%s\t%s\n", status, d.pos)
+		}
+	}
+
+	return r.result(ok)
+}
+
+// runDirective runs d found in pkg with the configuration files of configs, reports its
+// problems to r, and reports whether it succeeded. The packages of d are looked up in loaded by directory, and
+// added to it when they must be loaded.
+func runDirective(fs afero.Fs, configs *configLoader, loaded map[string]*wrapper.Package, pkg *wrapper.Package, d *directive, r *reporter) bool {
+	flags, c := newFlagSet(commandName)
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(d.args); err != nil {
+		r.reportAt(d.pos, err)
+		return false
+	}
+	if c.templates != "" && !filepath.IsAbs(c.templates) {
+		// go generate runs the directive in the directory of the package
+		c.templates = filepath.Join(pkg.Dir, c.templates)
+	}
+	c.explicit = flagSettings(flags, c, false)
+	if len(c.types()) == 0 && !c.all {
+		r.reportAt(d.pos, errors.New("-type or -all must be set"))
+		return false
+	}
+
+	pkgs := []*wrapper.Package{pkg}
+	if patterns := flags.Args(); len(patterns) > 0 {
+		pkgs = nil
+		for _, pattern := range patterns {
+			// go generate runs the directive in the directory of the package
+			dir := strings.TrimSuffix(pattern, "/...")
+			if !filepath.IsAbs(dir) {
+				if info, err := os.Stat(filepath.Join(pkg.Dir, dir)); err == nil && info.IsDir() {
+					pattern = filepath.Join(pkg.Dir, pattern)
+				}
+			}
+
+			if p, ok := loaded[pattern]; ok {
+				pkgs = append(pkgs, p)
+				continue
+			}
+			parsed, err := wrapper.ParsePackages(pattern)
+			if err != nil {
+				r.reportAt(d.pos, err)
+				return false
+			}
+			for _, p := range parsed {
+				if p.Dir == pattern {
+					loaded[pattern] = p
+				}
+			}
+			pkgs = append(pkgs, parsed...)
+		}
+	}
+
+	return run(fs, pkgs, c, configs, r)
+}
+
+// findDirectives returns the go:generate directives running type-wrapper in the files of pkg.
+func findDirectives(pkg *wrapper.Package) ([]*directive, error) {
+	var directives []*directive
+	for _, file := range pkg.Syntax {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				pos := pkg.Fset.Position(comment.Pos())
+				// go generate only runs directives at the beginning of a line
+				if !strings.HasPrefix(comment.Text, generatePrefix) || pos.Column != 1 {
+					continue
+				}
+
+				words, err := splitDirective(strings.TrimPrefix(comment.Text, generatePrefix))
+				if err != nil {
+					return nil, &wrapper.Diagnostic{Pos: pos, Code: wrapper.CodeError, Message: err.Error()}
+				}
+				args, ok := commandArgs(words)
+				if !ok {
+					continue
+				}
+
+				for i, arg := range args {
+					args[i] = os.Expand(arg, func(name string) string {
+						return expandVar(pkg, pos, name)
+					})
+				}
+				directives = append(directives, &directive{pos: pos, args: args})
+			}
+		}
+	}
+
+	return directives, nil
+}
+
+// splitDirective splits the command of a go:generate directive into words, which are
+// separated by spaces or double-quoted strings in Go syntax like go generate does.
+func splitDirective(line string) ([]string, error) {
+	var words []string
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimLeft(line, " \t") {
+		if line[0] != '"' {
+			i := strings.IndexAny(line, " \t")
+			if i < 0 {
+				i = len(line)
+			}
+			words = append(words, line[:i])
+			line = line[i:]
+			continue
+		}
+
+		i := 1
+		for ; i < len(line) && line[i] != '"'; i++ {
+			if line[i] == '\\' {
+				i++
+			}
+		}
+		if i >= len(line) {
+			return nil, errors.New("unterminated quoted string")
+		}
+		word, err := strconv.Unquote(line[:i+1])
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+		line = line[i+1:]
+	}
+
+	return words, nil
+}
+
+// commandArgs returns the arguments of type-wrapper if words run it directly or
+// with go run, and reports whether they do.
+func commandArgs(words []string) ([]string, bool) {
+	if len(words) == 0 {
+		return nil, false
+	}
+	if path.Base(words[0]) == commandName {
+		return words[1:], true
+	}
+
+	if len(words) < 3 || words[0] != "go" || words[1] != "run" {
+		return nil, false
+	}
+	for i, word := range words[2:] {
+		if strings.HasPrefix(word, "-") {
+			// a flag of go run
+			continue
+		}
+		pkgPath := strings.SplitN(word, "@", 2)[0]
+		if path.Base(pkgPath) != commandName {
+			return nil, false
+		}
+		return words[i+3:], true
+	}
+
+	return nil, false
+}
+
+// expandVar returns the value of the variable name in a go:generate directive at pos in pkg.
+func expandVar(pkg *wrapper.Package, pos token.Position, name string) string {
+	switch name {
+	case "GOFILE":
+		return filepath.Base(pos.Filename)
+	case "GOLINE":
+		return strconv.Itoa(pos.Line)
+	case "GOPACKAGE":
+		return pkg.Name
+	case "DOLLAR":
+		return "$"
+	}
+	return os.Getenv(name)
+}
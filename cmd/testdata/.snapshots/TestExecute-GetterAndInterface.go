@@ -7,7 +7,7 @@ type ITester interface {
 	GetSecondField() int32
 }
 
-// TesterWrapper encapulates the type Tester
+// TesterWrapper encapsulates the type Tester
 type TesterWrapper struct {
 	Tester
 }
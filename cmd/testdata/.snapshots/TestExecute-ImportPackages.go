@@ -3,12 +3,12 @@ package test
 
 import (
 	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/import_packages/sub1"
-	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/import_packages/sub2"
+	sub "github.com/Marble-Technologies/type-wrapper/cmd/testdata/import_packages/sub2"
 	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/import_packages/sub3"
 	"time"
 )
 
-// TesterWrapper encapulates the type Tester
+// TesterWrapper encapsulates the type Tester
 type TesterWrapper struct {
 	Tester
 }
@@ -29,11 +29,11 @@ func (t TesterWrapper) SetField2(val *sub1.SubTester) {
 	t.Tester.field2 = val
 }
 
-func (t TesterWrapper) Field3() *sub2.SubTester {
+func (t TesterWrapper) Field3() *sub.SubTester {
 	return t.Tester.field3
 }
 
-func (t TesterWrapper) SetField3(val *sub2.SubTester) {
+func (t TesterWrapper) SetField3(val *sub.SubTester) {
 	t.Tester.field3 = val
 }
 
@@ -3,6 +3,7 @@ package test
 
 import (
 	"encoding/json"
+	"io"
 )
 
 type ITester interface {
@@ -11,48 +12,103 @@ type ITester interface {
 	GetField2() int32
 	SetField2(val int32)
 	Read(p []byte) (int, error)
+	Reset()
+	WriteTo(w io.Writer) (int64, error)
+	MarshalJSON() ([]byte, error)
 }
 
-// TesterWrapper encapulates the type Tester
+var _ ITester = (*TesterWrapper)(nil)
+
+// TesterWrapper encapsulates the type Tester
 type TesterWrapper struct {
-	// The name of the original type, it gets initalized when calling Read() function, DO NOT USE IT
+	// The name of the original type, it gets initialized when encoding the wrapper, DO NOT USE IT
 	DataType string `json:"_data_type,omitempty"`
 	Tester
+	// encoded bytes streamed by Read
+	readBuf []byte
+	readOff int
 }
 
-func (t TesterWrapper) GetField1() string {
+func (t *TesterWrapper) GetField1() string {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	return t.Tester.field1
 }
 
-func (t TesterWrapper) SetField1(val string) {
+func (t *TesterWrapper) SetField1(val string) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	t.Tester.field1 = val
 }
 
-func (t TesterWrapper) GetField2() int32 {
+func (t *TesterWrapper) GetField2() int32 {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	return t.Tester.field2
 }
 
-func (t TesterWrapper) SetField2(val int32) {
+func (t *TesterWrapper) SetField2(val int32) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 	t.Tester.field2 = val
 }
 
-func (t TesterWrapper) Read(p []byte) (int, error) {
+// Read reads the JSON encoding of TesterWrapper. Successive calls stream
+// the encoded bytes and return io.EOF once all of them have been read.
+func (t *TesterWrapper) Read(p []byte) (int, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.readBuf == nil {
+		value := struct {
+			DataType string `json:"_data_type,omitempty"`
+			*Tester
+		}{
+			DataType: "Tester",
+			Tester:   &t.Tester,
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return 0, err
+		}
+		t.readBuf = data
+	}
+	if t.readOff >= len(t.readBuf) {
+		return 0, io.EOF
+	}
+	n := copy(p, t.readBuf[t.readOff:])
+	t.readOff += n
+	return n, nil
+}
+
+// Reset discards the state of Read, so that the next Read encodes TesterWrapper again.
+func (t *TesterWrapper) Reset() {
 	t.lock.Lock()
 	defer t.lock.Unlock()
-	t.DataType = "Tester"
-	data, err := json.Marshal(t)
+	t.readBuf = nil
+	t.readOff = 0
+}
+
+// WriteTo writes the JSON encoding of TesterWrapper to w.
+func (t *TesterWrapper) WriteTo(w io.Writer) (int64, error) {
+	data, err := t.MarshalJSON()
 	if err != nil {
 		return 0, err
 	}
-	n := copy(p, data)
-	return n, nil
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// MarshalJSON returns the JSON encoding of Tester tagged with its type name.
+func (t *TesterWrapper) MarshalJSON() ([]byte, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	value := struct {
+		DataType string `json:"_data_type,omitempty"`
+		*Tester
+	}{
+		DataType: "Tester",
+		Tester:   &t.Tester,
+	}
+	return json.Marshal(value)
 }
 
@@ -1,7 +1,7 @@
 // Code generated by type-wrapper; DO NOT EDIT.
 package test
 
-// TesterWrapper encapulates the type Tester
+// TesterWrapper encapsulates the type Tester
 type TesterWrapper struct {
 	Tester
 }
@@ -3,19 +3,26 @@ package test
 
 import (
 	"encoding/json"
+	"io"
 )
 
 type ITester interface {
 	Field1() string
 	SetSecondField(val int32)
 	Read(p []byte) (int, error)
+	Reset()
+	WriteTo(w io.Writer) (int64, error)
+	MarshalJSON() ([]byte, error)
 }
 
-// TesterWrapper encapulates the type Tester
+// TesterWrapper encapsulates the type Tester
 type TesterWrapper struct {
-	// The name of the original type, it gets initalized when calling Read() function, DO NOT USE IT
+	// The name of the original type, it gets initialized when encoding the wrapper, DO NOT USE IT
 	DataType string `json:"_data_type,omitempty"`
 	Tester
+	// encoded bytes streamed by Read
+	readBuf []byte
+	readOff int
 }
 
 func (tester TesterWrapper) Field1() string {
@@ -26,13 +33,49 @@ func (tester TesterWrapper) SetSecondField(val int32) {
 	tester.Tester.field2 = val
 }
 
-func (tester TesterWrapper) Read(p []byte) (int, error) {
-	tester.DataType = "Tester"
-	data, err := json.Marshal(tester)
+// Read reads the JSON encoding of TesterWrapper. Successive calls stream
+// the encoded bytes and return io.EOF once all of them have been read.
+func (tester *TesterWrapper) Read(p []byte) (int, error) {
+	if tester.readBuf == nil {
+		data, err := tester.MarshalJSON()
+		if err != nil {
+			return 0, err
+		}
+		tester.readBuf = data
+	}
+	if tester.readOff >= len(tester.readBuf) {
+		return 0, io.EOF
+	}
+	n := copy(p, tester.readBuf[tester.readOff:])
+	tester.readOff += n
+	return n, nil
+}
+
+// Reset discards the state of Read, so that the next Read encodes TesterWrapper again.
+func (tester *TesterWrapper) Reset() {
+	tester.readBuf = nil
+	tester.readOff = 0
+}
+
+// WriteTo writes the JSON encoding of TesterWrapper to w.
+func (tester TesterWrapper) WriteTo(w io.Writer) (int64, error) {
+	data, err := tester.MarshalJSON()
 	if err != nil {
 		return 0, err
 	}
-	n := copy(p, data)
-	return n, nil
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// MarshalJSON returns the JSON encoding of Tester tagged with its type name.
+func (tester TesterWrapper) MarshalJSON() ([]byte, error) {
+	value := struct {
+		DataType string `json:"_data_type,omitempty"`
+		*Tester
+	}{
+		DataType: "Tester",
+		Tester:   &tester.Tester,
+	}
+	return json.Marshal(value)
 }
 
@@ -0,0 +1,18 @@
+package test
+
+type Item struct {
+	Name string
+}
+
+type Inner struct {
+	total uint32 `wrapper:"getter,setter,atomic"`
+}
+
+// Tester has fields with atomic accessors, which Clone loads atomically.
+type Tester struct {
+	Inner
+	hits  int64    `wrapper:"getter,setter,atomic"`
+	owner *Item    `wrapper:"getter,setter,atomic"`
+	done  bool     `wrapper:"getter,setter,atomic"`
+	tags  []string `wrapper:"getter"`
+}
@@ -0,0 +1,34 @@
+package test
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCloneRace(t *testing.T) {
+	w := &TesterWrapper{Tester: Tester{tags: []string{"a"}}}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			w.AddHits(1)
+			w.AddTotal(1)
+			w.SetOwner(&Item{Name: "owner"})
+			w.SetDone(i%2 == 0)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		clone := w.Clone()
+		if clone.Hits() > w.Hits() {
+			t.Fatalf("clone has %d hits, more than %d", clone.Hits(), w.Hits())
+		}
+	}
+	wg.Wait()
+
+	clone := w.Clone()
+	if clone.Hits() != 1000 || clone.Total() != 1000 || clone.Owner() != w.Owner() {
+		t.Errorf("want 1000 hits and total and the owner, got %d, %d and %v", clone.Hits(), clone.Total(), clone.Owner())
+	}
+}
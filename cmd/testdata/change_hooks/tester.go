@@ -0,0 +1,19 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Tester struct {
+	mu      sync.Mutex
+	hits    int64             `wrapper:"getter,setter,atomic"`
+	name    string            `wrapper:"getter,setter"`
+	tags    []string          `wrapper:"getter,setter,copy"`
+	expires time.Time         `wrapper:"getter,setter,lock:-"`
+	labels  map[string]string `wrapper:"getter"`
+	done    bool              `wrapper:"getter,setter,atomic"`
+	owner   *Tester           `wrapper:"getter,setter,atomic"`
+	count   atomic.Int32      `wrapper:"getter,setter,atomic"`
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAtomicHooks(t *testing.T) {
+	w := &TesterWrapper{}
+
+	var mu sync.Mutex
+	var changes []string
+	w.OnChange(func(field string, old, new any) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, fmt.Sprintf("%s %v->%v", field, old, new))
+	})
+	var total int64
+	w.OnHitsChange(func(old, new int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		total += new - old
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				w.AddHits(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if total != 200 {
+		t.Errorf("want hooks to add up to 200 hits, got %d", total)
+	}
+
+	changes = nil
+	w.SetHits(1)
+	w.SwapHits(2)
+	w.CompareAndSwapHits(2, 3)
+	w.CompareAndSwapHits(2, 4)
+	w.SetDone(true)
+	w.CompareAndSwapDone(true, false)
+	w.AddCount(5)
+	w.SwapCount(7)
+	want := []string{
+		"hits 200->1", "hits 1->2", "hits 2->3",
+		"done false->true", "done true->false",
+		"count 0->5", "count 5->7",
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("want changes\n%v\ngot\n%v", want, changes)
+	}
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type Tester struct {
+	field1 string       `wrapper:"getter,setter"`
+	field2 int          `wrapper:"getter:Field1"`
+	count  atomic.Int64 `wrapper:"getter,setter,atomic"`
+	status string       `wrapper:"getter"`
+}
+
+// Status returns the status of the tester, which a Status getter would shadow.
+func (t *Tester) Status() string {
+	return "status: " + t.status
+}
+
+// AddCount adds delta to the count, which an AddCount method would shadow.
+func (t *Tester) AddCount(delta int64) int64 {
+	return t.count.Add(delta)
+}
+
+// Timed has methods shadowing every accessor of a field of another package.
+type Timed struct {
+	created time.Time `wrapper:"getter,setter"`
+	name    string    `wrapper:"getter"`
+}
+
+// Created returns the creation time, which a Created getter would shadow.
+func (t *Timed) Created() time.Time {
+	return t.created
+}
+
+// SetCreated sets the creation time, which a SetCreated setter would shadow.
+func (t *Timed) SetCreated(created time.Time) {
+	t.created = created
+}
@@ -0,0 +1,30 @@
+// Code generated by type-wrapper; DO NOT EDIT.
+package test
+
+import (
+	"slices"
+)
+
+// ChildWrapper encapsulates the type Child
+type ChildWrapper struct {
+	Child
+}
+
+func (c *ChildWrapper) Values() []int {
+	return slices.Clone(c.Child.values)
+}
+
+func (c *ChildWrapper) SetValues(val []int) {
+	c.Child.values = slices.Clone(val)
+}
+
+// Clone returns a deep copy of c, whose slices, maps and pointed-to values are copies.
+func (c *ChildWrapper) Clone() *ChildWrapper {
+	if c == nil {
+		return nil
+	}
+	clone := &ChildWrapper{}
+	clone.Child = c.Child
+	clone.Child.values = slices.Clone(c.Child.values)
+	return clone
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Item struct {
+	Name string
+}
+
+// Child is wrapped by ChildWrapper, whose Clone method copies it deeply.
+type Child struct {
+	values []int `wrapper:"getter,setter"`
+}
+
+// Meta is a struct value holding a slice, which copies of it copy.
+type Meta struct {
+	Tags []string
+}
+
+// Cache holds a lock, which copies of it leave zero while copying its other fields.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]int
+	size    int
+}
+
+// Node refers to itself, so copies of it share the nodes after the next one.
+type Node struct {
+	Values []int
+	Next   *Node
+}
+
+// Audit is embedded by Tester, which promotes its fields.
+type Audit struct {
+	Notes []string `wrapper:"getter"`
+}
+
+type Tester struct {
+	Audit
+	mu       sync.RWMutex
+	hits     atomic.Int64
+	meta     Meta `wrapper:"getter,setter"`
+	cache    Cache
+	tags     []string          `wrapper:"getter,setter,lock:mu"`
+	labels   map[string]string `wrapper:"getter,setter,lock:mu"`
+	owner    *Item             `wrapper:"getter,setter"`
+	groups   map[string][]int  `wrapper:"getter,setter"`
+	matrix   [][]float64       `wrapper:"getter"`
+	corners  [2]*Item          `wrapper:"getter,setter"`
+	children []*ChildWrapper   `wrapper:"getter,setter"`
+	name     string            `wrapper:"getter,setter"`
+	head     *Node             `wrapper:"getter,setter"`
+	updated  time.Time         `wrapper:"getter,setter"`
+}
+
+// Tagged copies the fields tagged with copy only.
+type Tagged struct {
+	tags   []string `wrapper:"getter:copy,setter"`
+	owner  *Item    `wrapper:"getter,setter,copy"`
+	shared []byte   `wrapper:"getter,setter"`
+}
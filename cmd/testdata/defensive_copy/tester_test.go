@@ -0,0 +1,44 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeepClone(t *testing.T) {
+	w := &TesterWrapper{Tester: Tester{
+		Audit: Audit{Notes: []string{"note"}},
+		meta:  Meta{Tags: []string{"tag"}},
+		cache: Cache{entries: map[string]int{"a": 1}, size: 1},
+		name:  "name",
+		head:  &Node{Values: []int{1}},
+	}}
+	w.Tester.hits.Store(3)
+
+	clone := w.Clone()
+	if clone.Name() != "name" || clone.Tester.hits.Load() != 3 {
+		t.Errorf("want the fields of the struct holding a lock copied, got name %q and %d hits", clone.Name(), clone.Tester.hits.Load())
+	}
+
+	if clone.Tester.cache.size != 1 || clone.Tester.cache.entries["a"] != 1 {
+		t.Errorf("want the fields of the cache besides its lock copied, got entries %v and size %d", clone.Tester.cache.entries, clone.Tester.cache.size)
+	}
+
+	clone.Tester.cache.entries["a"] = 2
+	clone.Tester.meta.Tags[0] = "changed"
+	clone.Tester.Notes[0] = "changed"
+	w.Meta().Tags[0] = "changed"
+	clone.Tester.head.Values[0] = 2
+	if w.Tester.cache.entries["a"] != 1 {
+		t.Errorf("want the cache of the original unchanged, got %v", w.Tester.cache.entries)
+	}
+	if !reflect.DeepEqual(w.Meta(), Meta{Tags: []string{"tag"}}) {
+		t.Errorf("want the meta of the original unchanged, got %v", w.Meta())
+	}
+	if !reflect.DeepEqual(w.Head().Values, []int{1}) {
+		t.Errorf("want the values of the head of the original unchanged, got %v", w.Head().Values)
+	}
+	if !reflect.DeepEqual(w.Notes(), []string{"note"}) {
+		t.Errorf("want the promoted notes of the original unchanged, got %v", w.Notes())
+	}
+}
@@ -0,0 +1,13 @@
+package hidden
+
+type private struct {
+	Value int
+}
+
+// Public is an exported alias of an unexported type.
+type Public = private
+
+// Base has a tagged field which is unexported in this package.
+type Base struct {
+	revision int `wrapper:"getter"`
+}
@@ -0,0 +1,47 @@
+package diagnostics
+
+import (
+	"sync"
+
+	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/diagnostics/hidden"
+)
+
+type MalformedTag struct {
+	Field1 string `wrapper:"getter,setter:1Field"`
+	Field2 string `wrapper:"getter,unknown"`
+	Field3 string `wrapper:getter`
+}
+
+type Collision struct {
+	Field1 string `wrapper:"getter:Value"`
+	Field2 string `wrapper:"getter:Value"`
+	Field3 string `wrapper:"getter:Read"`
+}
+
+type UnexportedType struct {
+	Field1 hidden.Public   `wrapper:"getter"`
+	Field2 []hidden.Public `wrapper:"setter"`
+}
+
+type InvalidLock struct {
+	mu     sync.Mutex
+	count  int    `wrapper:"getter,lock:missing"`
+	name   string `wrapper:"getter,lock:count"`
+	values []int  `wrapper:"getter,lock:mu"`
+}
+
+type UnexportedPromotedField struct {
+	hidden.Base
+	Field1 *int `wrapper:"getter"`
+}
+
+type OtherTagKey struct {
+	Field1 string `json:"other" mywrapper:"x"`
+	Field2 string `json:"other" wrapper:getter`
+}
+
+type FieldCollision struct {
+	Field1 string `wrapper:"getter:readBuf"`
+	Field2 string `wrapper:"getter,setter"`
+	Field3 string `wrapper:"getter:onField2Change"`
+}
@@ -0,0 +1,9 @@
+package diagnostics
+
+import stdtime "time"
+
+// AtomicDuration has a field of a type without atomic accessors, of a package imported
+// under an alias, which diagnostics name the type with as the generated code does.
+type AtomicDuration struct {
+	Timeout stdtime.Duration `wrapper:"getter,atomic"`
+}
@@ -0,0 +1,7 @@
+package sub
+
+//go:generate type-wrapper -all -pointer -interface "I{{.Type}}" -wrapper "Wrapped{{.Type}}"
+
+type Tester struct {
+	Name string `wrapper:"getter"`
+}
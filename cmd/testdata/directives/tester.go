@@ -0,0 +1,12 @@
+package test
+
+//go:generate type-wrapper -type Tester -interface ITester
+//go:generate go run github.com/Marble-Technologies/type-wrapper -type OtherTester -receiver $GOPACKAGE -output other_$GOFILE
+
+type Tester struct {
+	Field1 string `wrapper:"getter,setter"`
+}
+
+type OtherTester struct {
+	Count int `wrapper:"getter"`
+}
@@ -0,0 +1,9 @@
+package base
+
+import "time"
+
+// Audit records the changes of a model.
+type Audit struct {
+	CreatedAt time.Time `wrapper:"getter"`
+	UpdatedBy string    `wrapper:"getter,setter"`
+}
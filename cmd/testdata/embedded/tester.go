@@ -0,0 +1,30 @@
+package test
+
+import (
+	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/embedded/base"
+)
+
+type Identity struct {
+	ID   string `wrapper:"getter"`
+	Name string `wrapper:"getter,setter"`
+}
+
+type Address struct {
+	City string `wrapper:"getter,setter"`
+}
+
+type Owner struct {
+	Name  string `wrapper:"getter,setter"`
+	Email string `wrapper:"getter,setter"`
+	Address
+}
+
+// Tester composes base structs: ID hides Identity.ID, and Identity.Name and
+// Owner.Name are ambiguous, so none of them is promoted.
+type Tester struct {
+	base.Audit
+	Identity
+	*Owner
+	ID    string
+	title string `wrapper:"getter,setter"`
+}
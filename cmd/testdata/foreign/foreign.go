@@ -0,0 +1,6 @@
+package foreign
+
+// Service is configured by a lib.Config through its wrapper.
+type Service struct {
+	name string
+}
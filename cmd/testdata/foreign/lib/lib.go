@@ -0,0 +1,42 @@
+package lib
+
+import (
+	"sync"
+	"time"
+)
+
+// Level is the level of the logs.
+type Level int
+
+// Config has no wrapper tags, so its wrapper has accessors for every exported field.
+type Config struct {
+	Name    string
+	Timeout time.Duration
+	Level   Level
+	Labels  map[string]string
+	secret  string
+}
+
+// Session tags an unexported field, which a wrapper in another package cannot access.
+type Session struct {
+	mu    sync.Mutex
+	ID    string `wrapper:"getter"`
+	token string `wrapper:"getter,setter"`
+}
+
+// Report has exported fields of a type which is unexported in this package.
+type Report struct {
+	Summary *summary  `wrapper:"getter"`
+	Lines   []summary `wrapper:"setter"`
+}
+
+type summary struct {
+	Text string
+}
+
+// Labeled has an exported field of an unexported alias of this package.
+type Labeled struct {
+	Label label `wrapper:"getter"`
+}
+
+type label = string
@@ -0,0 +1,5 @@
+package sub
+
+type Box[T any] struct {
+	Value T
+}
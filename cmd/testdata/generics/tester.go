@@ -0,0 +1,14 @@
+package test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/generics/sub"
+)
+
+type Tester[K comparable, V fmt.Stringer] struct {
+	field1 K                   `wrapper:"getter,setter"`
+	field2 []V                 `wrapper:"getter,setter"`
+	field3 *sub.Box[time.Time] `wrapper:"getter"`
+}
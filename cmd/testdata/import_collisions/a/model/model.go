@@ -0,0 +1,5 @@
+package model
+
+type Item struct {
+	Name string
+}
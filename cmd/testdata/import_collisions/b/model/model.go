@@ -0,0 +1,5 @@
+package model
+
+type Key struct {
+	ID int
+}
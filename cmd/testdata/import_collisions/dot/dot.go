@@ -0,0 +1,3 @@
+package dot
+
+type Status int
@@ -0,0 +1,9 @@
+package test
+
+import (
+	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/import_collisions/b/model"
+)
+
+type OtherTester struct {
+	field1 map[model.Key][]int `wrapper:"getter,setter"`
+}
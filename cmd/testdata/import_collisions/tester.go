@@ -0,0 +1,17 @@
+package test
+
+import (
+	"io"
+	"time"
+
+	"github.com/Marble-Technologies/type-wrapper/cmd/testdata/import_collisions/a/model"
+	. "github.com/Marble-Technologies/type-wrapper/cmd/testdata/import_collisions/dot"
+)
+
+type Tester struct {
+	field1 map[string]model.Item   `wrapper:"getter,setter"`
+	field2 []*time.Time            `wrapper:"getter,setter"`
+	field3 func(r io.Reader) error `wrapper:"getter,setter"`
+	field4 chan<- model.Item       `wrapper:"getter,setter"`
+	field5 *Status                 `wrapper:"getter,setter"`
+}
@@ -0,0 +1,17 @@
+package test
+
+import "time"
+
+type Tester struct {
+	field1 string `wrapper:"getter,setter"`
+	field2 int32  `wrapper:"getter:GetSecondField"`
+}
+
+type OtherTester struct {
+	field1 time.Time `wrapper:"getter"`
+	field2 *bool     `wrapper:"setter"`
+}
+
+type Untagged struct {
+	field1 string
+}
@@ -0,0 +1,9 @@
+package nested
+
+type Tester struct {
+	Name string `wrapper:"getter"`
+}
+
+type OtherTester struct {
+	Count int `wrapper:"getter,setter"`
+}
@@ -0,0 +1,5 @@
+package plain
+
+type Plain struct {
+	Field string
+}
@@ -0,0 +1,6 @@
+package test
+
+type Tester struct {
+	Field1 string `wrapper:"getter,setter"`
+	Field2 int32
+}
@@ -0,0 +1,7 @@
+package test
+
+type Tester struct {
+	Name  string
+	Count int
+	Tags  []string
+}
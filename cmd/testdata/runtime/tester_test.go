@@ -0,0 +1,40 @@
+package test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"testing/iotest"
+)
+
+func TestChunkedWrite(t *testing.T) {
+	src := &TesterWrapper{Tester: Tester{Name: "name", Count: 3, Tags: []string{"a", "b"}}}
+	var dst TesterWrapper
+
+	// OneByteReader hides WriterTo, so that io.Copy writes one byte at a time.
+	if _, err := io.Copy(&dst, iotest.OneByteReader(src)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dst.Tester, src.Tester) {
+		t.Errorf("want %+v, got %+v", src.Tester, dst.Tester)
+	}
+	if dst.DataType != "Tester" {
+		t.Errorf("want data type Tester, got %q", dst.DataType)
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	var dst TesterWrapper
+	if _, err := dst.Write([]byte("not a JSON value")); err == nil {
+		t.Fatal("want a syntax error")
+	}
+
+	// the syntax error discarded the buffered bytes, so the next values decode
+	payload := []byte(`{"_data_type":"Tester","Name":"first"}{"_data_type":"Tester","Name":"second"}`)
+	if _, err := dst.Write(payload); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "second" {
+		t.Errorf("want name second, got %q", dst.Name)
+	}
+}
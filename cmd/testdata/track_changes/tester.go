@@ -0,0 +1,29 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type Audit struct {
+	CreatedBy string `json:"created_by" wrapper:"getter:GetCreatedBy,setter"`
+}
+
+type Tester struct {
+	*Audit
+	mu      sync.RWMutex
+	Name    string            `json:"name" wrapper:"getter:GetName,setter,lock:mu"`
+	Tags    []string          `json:"tags,omitempty" wrapper:"getter:GetTags,setter,lock:mu"`
+	Secret  string            `json:"-" wrapper:"setter"`
+	Labels  map[string]string `wrapper:"getter:GetLabels,setter"`
+	Hits    int64             `json:"hits" wrapper:"getter:GetHits,setter,atomic"`
+	Version atomic.Int32      `json:"version" wrapper:"getter:GetVersion,setter,atomic"`
+	Size    int               `json:"size" wrapper:"getter:GetSize"`
+	Meta    `json:"meta"`
+	note    string `wrapper:"setter"`
+}
+
+// Meta is encoded in the object named by the json tag of the embedded field.
+type Meta struct {
+	Owner string `json:"owner" wrapper:"setter"`
+}
@@ -0,0 +1,21 @@
+package test
+
+import "testing"
+
+func TestChangedJSON(t *testing.T) {
+	w := &TesterWrapper{}
+	w.SetName("name")
+	w.SetOwner("owner")
+	w.SetNote("note")
+	w.SetLabels(map[string]string{"a": "b"})
+	w.SetSecret("secret")
+
+	data, err := w.ChangedJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Labels":{"a":"b"},"meta":{"owner":"owner"},"name":"name"}`
+	if string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+}
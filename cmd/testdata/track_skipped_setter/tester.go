@@ -0,0 +1,11 @@
+package test
+
+// Counter declares SetHits, so -collisions skip drops the setter of Hits and keeps its
+// other atomic methods, which mark it changed.
+type Counter struct {
+	Hits int64 `json:"hits" wrapper:"getter,setter,atomic"`
+}
+
+func (c *Counter) SetHits(hits int64) {
+	c.Hits = hits
+}
@@ -0,0 +1,27 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkippedSetter(t *testing.T) {
+	w := &CounterWrapper{}
+	w.AddHits(2)
+	data, err := w.ChangedJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"hits":2}`; string(data) != want {
+		t.Errorf("want %s, got %s", want, data)
+	}
+
+	w.ClearChanges()
+	if w.CompareAndSwapHits(1, 3) || w.IsChanged("Hits") {
+		t.Error("want a failed compare and swap to leave Hits unchanged")
+	}
+	w.SwapHits(4)
+	if changed := w.Changed(); !reflect.DeepEqual(changed, []string{"Hits"}) {
+		t.Errorf("want Hits changed by SwapHits, got %v", changed)
+	}
+}
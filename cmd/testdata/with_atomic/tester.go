@@ -0,0 +1,21 @@
+package test
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+type Config struct {
+	Name string
+}
+
+type Tester struct {
+	count   int64                  `wrapper:"getter,setter,atomic"`
+	flags   uint32                 `wrapper:"getter,atomic"`
+	paused  bool                   `wrapper:"getter,setter,atomic"`
+	current *Config                `wrapper:"getter,setter,atomic"`
+	raw     unsafe.Pointer         `wrapper:"getter,setter,atomic"`
+	total   atomic.Int64           `wrapper:"getter,setter,atomic"`
+	enabled atomic.Bool            `wrapper:"getter,setter,atomic"`
+	latest  atomic.Pointer[Config] `wrapper:"getter,setter,atomic"`
+}
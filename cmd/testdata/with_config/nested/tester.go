@@ -0,0 +1,12 @@
+package nested
+
+import "sync"
+
+type Tester struct {
+	Name string `wrapper:"getter"`
+}
+
+type OtherTester struct {
+	mu    sync.Mutex
+	Count int `wrapper:"getter,setter"`
+}
@@ -0,0 +1,9 @@
+package test
+
+type Tester struct {
+	Field1 string `wrapper:"getter,setter"`
+}
+
+type OtherTester struct {
+	Count int `wrapper:"getter"`
+}
@@ -0,0 +1,11 @@
+package test
+
+import "sync"
+
+type Tester struct {
+	lock   sync.RWMutex
+	metaMu sync.Mutex
+	field1 string            `wrapper:"getter,setter"`
+	field2 map[string]string `wrapper:"getter,setter,lock:metaMu"`
+	field3 int32             `wrapper:"getter,lock:-"`
+}
@@ -0,0 +1,10 @@
+package test
+
+import "sync"
+
+type Tester struct {
+	lock   sync.RWMutex
+	field1 string `wrapper:"getter,setter"`
+	field2 int32  `wrapper:"getter:GetSecondField"`
+	field3 *bool
+}
@@ -1,16 +1,17 @@
 module github.com/Marble-Technologies/type-wrapper
 
-go 1.18
+go 1.19
 
 require (
 	github.com/bradleyjkemp/cupaloy/v2 v2.7.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/afero v1.8.2
 	golang.org/x/tools v0.1.11
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
 	golang.org/x/sys v0.0.0-20211019181941-9d821ace8654 // indirect
 	golang.org/x/text v0.3.7 // indirect
@@ -9,5 +9,8 @@ import (
 )
 
 func main() {
-	cmd.Execute(afero.NewOsFs(), os.Args)
+	if err := cmd.Execute(afero.NewOsFs(), os.Args); err != nil {
+		// the problems are already reported on stderr
+		os.Exit(1)
+	}
 }
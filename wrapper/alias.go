@@ -0,0 +1,15 @@
+//go:build go1.23
+
+package wrapper
+
+import "go/types"
+
+// alias returns the type name and the type arguments of t if t is an alias, which
+// generated code refers to by its own name rather than by the type it denotes.
+func alias(t types.Type) (*types.TypeName, *types.TypeList, bool) {
+	a, ok := t.(*types.Alias)
+	if !ok {
+		return nil, nil, false
+	}
+	return a.Obj(), a.TypeArgs(), true
+}
@@ -0,0 +1,11 @@
+//go:build !go1.23
+
+package wrapper
+
+import "go/types"
+
+// alias reports that t is not an alias: go/types resolves aliases to the types they
+// denote before Go 1.23.
+func alias(types.Type) (*types.TypeName, *types.TypeList, bool) {
+	return nil, nil, false
+}
@@ -0,0 +1,197 @@
+package wrapper
+
+import (
+	"bytes"
+	"go/types"
+)
+
+const atomicPkgPath = "sync/atomic"
+
+// atomicFuncs maps basic types to the suffix of the sync/atomic functions handling them.
+var atomicFuncs = map[types.BasicKind]string{
+	types.Int32:         "Int32",
+	types.Int64:         "Int64",
+	types.Uint32:        "Uint32",
+	types.Uint64:        "Uint64",
+	types.Uintptr:       "Uintptr",
+	types.UnsafePointer: "Pointer",
+}
+
+// setupAtomic checks that field supports atomic accessors and sets the parameters
+// used to generate them. It returns the type of values loaded from the field.
+func (g *generator) setupAtomic(field *Field, params *genParameters) (types.Type, error) {
+	if !g.pointer {
+		return nil, newDiagnostic(field.Pos, CodeUnsupportedAtomic, "atomic accessors of field %s require pointer receivers", field.Name)
+	}
+
+	if field.throughPointer() {
+		return nil, newDiagnostic(field.Pos, CodeUnsupportedAtomic, "field %s is promoted through an embedded pointer, which atomic accessors cannot check for nil", field.Name)
+	}
+
+	name := makeExportable(field.Name)
+	params.Atomic = true
+	params.SwapMethod = "Swap" + name
+	params.CompareAndSwapMethod = "CompareAndSwap" + name
+	params.AddMethod = "Add" + name
+
+	switch t := field.Type.(type) {
+	case *types.Basic:
+		if fn, ok := atomicFuncs[t.Kind()]; ok {
+			params.AtomicFunc = fn
+			params.AtomicAdd = t.Kind() != types.UnsafePointer
+			params.Type = params.TypeString(t)
+			return t, nil
+		}
+		if t.Kind() == types.Bool {
+			// sync/atomic has no functions for bool, so the accessors call the
+			// methods of the wrapper updating the word of the field
+			params.AtomicBool = true
+			params.Type = params.TypeString(t)
+			return t, nil
+		}
+	case *types.Pointer:
+		params.AtomicFunc = "Pointer"
+		params.AtomicCast = true
+		params.Type = params.TypeString(t)
+		return t, nil
+	case *types.Named:
+		if p := t.Obj().Pkg(); p == nil || p.Path() != atomicPkgPath {
+			break
+		}
+		// Types of sync/atomic such as atomic.Int64 have their own methods.
+		valueType := atomicValueType(t)
+		if valueType == field.Type {
+			break
+		}
+		params.Type = params.TypeString(valueType)
+		params.AtomicAdd = isAtomicAddable(t)
+		return valueType, nil
+	}
+
+	return nil, newDiagnostic(field.Pos, CodeUnsupportedAtomic, "field %s of type %s does not support atomic accessors", field.Name, params.TypeString(field.Type))
+}
+
+// isAtomicAddable reports whether the atomic accessors of a field of type t have an Add method.
+func isAtomicAddable(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		_, ok := atomicFuncs[t.Kind()]
+		return ok && t.Kind() != types.UnsafePointer
+	case *types.Named:
+		if p := t.Obj().Pkg(); p == nil || p.Path() != atomicPkgPath {
+			return false
+		}
+		return types.NewMethodSet(types.NewPointer(t)).Lookup(t.Obj().Pkg(), "Add") != nil
+	}
+	return false
+}
+
+// isAtomicBool reports whether field is a bool field with atomic accessors, which get
+// the methods of the "atomic-bool" template.
+func isAtomicBool(field *Field) bool {
+	t, ok := field.Type.(*types.Basic)
+	return ok && t.Kind() == types.Bool && field.Tag.Atomic
+}
+
+// atomicBoolMethods returns the names of the unexported methods the wrapper gets for
+// the bool field named name with atomic accessors: the method returning the word of
+// the field, and the methods loading, swapping, and comparing and swapping it.
+func atomicBoolMethods(name string) (word, load, swap, compareAndSwap string) {
+	name = makeExportable(name)
+	return makeUnexportable(name) + "Word", "load" + name, "swap" + name, "compareAndSwap" + name
+}
+
+// BoolMethods returns the names of the methods of the bool field of p with atomic
+// accessors, as a map keyed by Word, Load, Swap and CompareAndSwap.
+func (p *genParameters) BoolMethods() map[string]string {
+	word, load, swap, compareAndSwap := atomicBoolMethods(p.Field)
+	return map[string]string{"Word": word, "Load": load, "Swap": swap, "CompareAndSwap": compareAndSwap}
+}
+
+// atomicCall is the data of the "atomic-call" template, which calls method on the field.
+type atomicCall struct {
+	*genParameters
+	Method string
+	Args   []string
+}
+
+// call returns the data to call method on the field with args.
+// Values of pointer fields are converted to unsafe.Pointer, and bool fields are
+// stored by swapping them.
+func (p *genParameters) call(method string, args ...string) *atomicCall {
+	if p.AtomicBool {
+		if method == "Store" {
+			method = "Swap"
+		}
+		method = p.BoolMethods()[method]
+	}
+	if p.AtomicCast {
+		for i := range args {
+			args[i] = "unsafe.Pointer(" + args[i] + ")"
+		}
+	}
+	return &atomicCall{genParameters: p, Method: method, Args: args}
+}
+
+func (g *generator) generateAtomicGetter(
+	params *genParameters,
+) (string, error) {
+	return g.executeAtomic("atomic-getter", params)
+}
+
+func (g *generator) generateAtomicSetter(
+	params *genParameters,
+) (string, error) {
+	return g.executeAtomic("atomic-setter", params)
+}
+
+func (g *generator) generateAtomicBool(
+	params *genParameters,
+) (string, error) {
+	return g.execute("atomic-bool", params)
+}
+
+func (g *generator) generateAtomicSetterInterface(
+	params *genParameters,
+) (string, error) {
+	if params.Interface == "" {
+		return "", nil
+	}
+	return g.executeAtomic("atomic-setter-interface", params)
+}
+
+// executeAtomic executes an atomic accessor template with the calls it uses.
+func (g *generator) executeAtomic(name string, params *genParameters) (string, error) {
+	return g.execute(name, atomicData(params))
+}
+
+// atomicLoad returns the expression loading the value of the atomic field of params
+// like its getter does.
+func (g *generator) atomicLoad(params *genParameters) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := g.templates.ExecuteTemplate(buf, "atomic-load", atomicData(params)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// atomicTemplateData is the data of the atomic accessor templates.
+type atomicTemplateData struct {
+	*genParameters
+	Load, Store, Swap, CompareAndSwap, Add *atomicCall
+	SwapVal                                *atomicCall // Swap storing val, with which setters calling hooks get the old value
+}
+
+// atomicData returns the data of the atomic accessor templates of params, with the
+// calls they use.
+func atomicData(params *genParameters) *atomicTemplateData {
+	return &atomicTemplateData{
+		genParameters:  params,
+		Load:           params.call("Load"),
+		Store:          params.call("Store", "val"),
+		Swap:           params.call("Swap", "new"),
+		SwapVal:        params.call("Swap", "val"),
+		CompareAndSwap: params.call("CompareAndSwap", "old", "new"),
+		Add:            params.call("Add", "delta"),
+	}
+}
@@ -0,0 +1,77 @@
+package wrapper
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+// Check generates the wrappers of pkg in memory and compares them with the files in fs.
+// It returns a unified diff of the stale files from fs to the generated ones, or an
+// empty string if every file is up to date.
+func Check(fs afero.Fs, pkg *Package, options ...Option) (string, error) {
+	generated, paths, err := generateInMemory(pkg, options...)
+	if err != nil {
+		return "", err
+	}
+
+	var diffs strings.Builder
+	for _, path := range paths {
+		want := generated[path]
+		got, err := afero.ReadFile(fs, path)
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		if bytes.Equal(got, want) {
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(got),
+			B:        splitLines(want),
+			FromFile: path,
+			ToFile:   path + " (generated)",
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		diffs.WriteString(diff)
+	}
+
+	return diffs.String(), nil
+}
+
+// generateInMemory generates the wrappers of pkg in memory, and returns their
+// contents by path with the sorted paths.
+func generateInMemory(pkg *Package, options ...Option) (map[string][]byte, []string, error) {
+	if newGenerator(options...).output == stdoutOutput {
+		return nil, nil, errors.New("generated files cannot be compared when writing them to stdout")
+	}
+
+	generated, err := GenerateFiles(pkg, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paths := make([]string, 0, len(generated))
+	for path := range generated {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return generated, paths, nil
+}
+
+// splitLines splits src into lines for a diff; a missing or empty file has no lines.
+func splitLines(src []byte) []string {
+	if len(src) == 0 {
+		return nil
+	}
+	return difflib.SplitLines(string(src))
+}
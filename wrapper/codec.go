@@ -0,0 +1,169 @@
+package wrapper
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	codecJSON = "json"
+	codecXML  = "xml"
+	codecGob  = "gob"
+)
+
+// customCodecAlias is the import name of the package of a custom codec.
+const customCodecAlias = "codec"
+
+// codec describes how generated wrappers encode and decode the original type.
+type codec struct {
+	Name            string // name of the encoding used in comments, such as JSON
+	DataTypeTag     string // struct tag of the type name field; empty if the codec has no tags
+	EmbeddedTag     string // struct tag of the embedded original type
+	XMLName         bool   // the encoding has a root element named after the original type
+	MarshalMethod   string
+	UnmarshalMethod string
+
+	imports       []*packages.Package
+	decoderFunc   string // function returning a decoder of a stream of values, such as json.NewDecoder
+	marshalFunc   string // function marshaling a value, such as json.Marshal; empty for gob
+	unmarshalFunc string // function unmarshaling data, such as json.Unmarshal; empty for gob
+}
+
+// majorVersion matches the major version suffix of module paths, such as v5.
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// newCodec returns the codec named name, which is json, xml, gob, or the
+// qualified name of a custom Marshal(any) ([]byte, error) function such as
+// gopkg.in/yaml.v3.Marshal. tag is the struct tag key of a custom codec.
+func newCodec(name, tag string) (*codec, error) {
+	switch name {
+	case "", codecJSON:
+		return &codec{
+			Name:            "JSON",
+			DataTypeTag:     `json:"_data_type,omitempty"`,
+			MarshalMethod:   "MarshalJSON",
+			UnmarshalMethod: "UnmarshalJSON",
+			imports:         []*packages.Package{stdPackage("encoding/json")},
+			decoderFunc:     "json.NewDecoder",
+			marshalFunc:     "json.Marshal",
+			unmarshalFunc:   "json.Unmarshal",
+		}, nil
+	case codecXML:
+		return &codec{
+			Name:            "XML",
+			DataTypeTag:     `xml:"_data_type,attr,omitempty"`,
+			XMLName:         true,
+			MarshalMethod:   "Marshal",
+			UnmarshalMethod: "Unmarshal",
+			imports:         []*packages.Package{stdPackage("encoding/xml")},
+			marshalFunc:     "xml.Marshal",
+			unmarshalFunc:   "xml.Unmarshal",
+		}, nil
+	case codecGob:
+		return &codec{
+			Name:            "gob",
+			MarshalMethod:   "GobEncode",
+			UnmarshalMethod: "GobDecode",
+			imports:         []*packages.Package{stdPackage("bytes"), stdPackage("encoding/gob")},
+		}, nil
+	}
+
+	i := strings.LastIndex(name, ".")
+	if i <= 0 || i < strings.LastIndex(name, "/") {
+		return nil, fmt.Errorf("unknown codec %q; use json, xml, gob or the qualified name of a Marshal function", name)
+	}
+	pkgPath, marshal := name[:i], name[i+1:]
+	if tag == "" {
+		tag = defaultCodecTag(pkgPath)
+	}
+
+	var unmarshal string
+	if strings.Contains(marshal, "Marshal") {
+		unmarshal = customCodecAlias + "." + strings.Replace(marshal, "Marshal", "Unmarshal", 1)
+	}
+
+	return &codec{
+		Name:            tag,
+		DataTypeTag:     tag + `:"_data_type,omitempty"`,
+		EmbeddedTag:     tag + `:",inline"`,
+		MarshalMethod:   "Marshal",
+		UnmarshalMethod: "Unmarshal",
+		imports: []*packages.Package{
+			{ID: pkgPath, Name: customCodecAlias, PkgPath: pkgPath},
+		},
+		marshalFunc:   customCodecAlias + "." + marshal,
+		unmarshalFunc: unmarshal,
+	}, nil
+}
+
+// defaultCodecTag returns the struct tag key of a custom codec in pkgPath,
+// which is the package name without version, such as yaml for gopkg.in/yaml.v3.
+func defaultCodecTag(pkgPath string) string {
+	name := path.Base(pkgPath)
+	if majorVersion.MatchString(name) {
+		name = path.Base(path.Dir(pkgPath))
+	}
+	if i := strings.Index(name, "."); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// canUnmarshal reports whether the codec can decode data.
+func (c *codec) canUnmarshal() bool {
+	return c.marshalFunc == "" || c.unmarshalFunc != ""
+}
+
+// Marshal returns statements that return the encoding of value and an error.
+func (c *codec) Marshal(value string) string {
+	if c.marshalFunc == "" {
+		return fmt.Sprintf(`var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(%s); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil`, value)
+	}
+	return fmt.Sprintf("return %s(%s)", c.marshalFunc, value)
+}
+
+// Encode returns statements that declare data, the encoding of value, and err.
+func (c *codec) Encode(value string) string {
+	if c.marshalFunc == "" {
+		return fmt.Sprintf(`var encoded bytes.Buffer
+		err := gob.NewEncoder(&encoded).Encode(%s)
+		data := encoded.Bytes()`, value)
+	}
+	return fmt.Sprintf("data, err := %s(%s)", c.marshalFunc, value)
+}
+
+// Unmarshal returns an expression that decodes data into value and returns an error.
+func (c *codec) Unmarshal(data, value string) string {
+	if c.unmarshalFunc == "" {
+		return fmt.Sprintf("gob.NewDecoder(bytes.NewReader(%s)).Decode(%s)", data, value)
+	}
+	return fmt.Sprintf("%s(%s, %s)", c.unmarshalFunc, data, value)
+}
+
+// Streams reports whether the codec decodes a stream of values, telling a value cut
+// short from a syntax error. Otherwise Write decodes the written bytes until the
+// codec stops failing with an unexpected end of data.
+func (c *codec) Streams() bool {
+	return c.decoderFunc != ""
+}
+
+// NewDecoder returns an expression returning a decoder of the values read from r.
+func (c *codec) NewDecoder(r string) string {
+	return fmt.Sprintf("%s(%s)", c.decoderFunc, r)
+}
+
+// Tag returns tag as a raw string literal, or nothing if tag is empty.
+func (c *codec) Tag(tag string) string {
+	if tag == "" {
+		return ""
+	}
+	return "`" + tag + "`"
+}
@@ -0,0 +1,454 @@
+package wrapper
+
+import (
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// cloneMethod is the name of the method returning a deep copy of a value, which the
+// wrappers generated with -defensive-copy have and copying accessors call.
+const cloneMethod = "Clone"
+
+// copier writes the code copying the values of fields, so that the values of slices,
+// maps and pointers returned by getters and passed to setters are not shared with
+// the wrapped struct. Values of types with a Clone method returning their own type,
+// such as wrappers generated with -defensive-copy, are copied by Clone. Structs are
+// copied by assignment and then their fields sharing memory are copied, unless they
+// contain a lock, which is left zero while the other fields are copied one by one.
+// Named structs are copied by a method of the wrapper, which the accessors and Clone
+// copying values of the same type share. The fields of structs of other packages
+// which pkg cannot access are shared, and so are the values a recursive type refers
+// to beyond its first recurrence.
+type copier struct {
+	pkg     *Package // package of the generated code
+	st      *Struct  // the wrapped struct, whose fields and methods the helpers must not shadow
+	params  *genParameters
+	copying []types.Type  // structs being copied, outermost first
+	helpers []*copyHelper // methods copying named structs, in the order they are first used
+}
+
+// copyHelper is the data of the "copy" template, which copies the struct pointed to
+// by src to the struct pointed to by dst.
+type copyHelper struct {
+	*genParameters
+	Method string // name of the method
+	Type   string // the copied struct type
+	Body   string // statements copying *src to *dst
+	typ    types.Type
+}
+
+// copyOut returns the statements of a getter returning a copy of the value src of type t.
+func (c *copier) copyOut(t types.Type, src string) string {
+	if expr, ok := c.expr(t, src); ok {
+		return "return " + expr
+	}
+	return fmt.Sprintf("var val %s\n%sreturn val", c.params.TypeString(t), c.assign(t, "val", src, 0, true))
+}
+
+// copyIn returns the statements of a setter assigning a copy of the value src of type t
+// to dst. dst is zero if zeroed is set, which spares assigning it nil.
+func (c *copier) copyIn(t types.Type, dst, src string, zeroed bool) string {
+	return strings.TrimSuffix(c.assign(t, dst, src, 0, zeroed), "\n")
+}
+
+// expr returns an expression evaluating to a copy of src of type t, and reports
+// whether there is one; copies of nested values need statements.
+func (c *copier) expr(t types.Type, src string) (string, bool) {
+	if !c.needsCopy(t) {
+		return src, true
+	}
+	if hasCloneMethod(t) && !isNillable(t) {
+		if strings.HasPrefix(src, "*") {
+			src = "(" + src + ")"
+		}
+		return src + "." + cloneMethod + "()", true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		if !hasCloneMethod(t) && !c.needsCopy(u.Elem()) {
+			c.params.imports.addImport(stdPackage("slices"))
+			return "slices.Clone(" + src + ")", true
+		}
+	case *types.Map:
+		if !hasCloneMethod(t) && !c.needsCopy(u.Elem()) {
+			c.params.imports.addImport(stdPackage("maps"))
+			return "maps.Clone(" + src + ")", true
+		}
+	}
+	return "", false
+}
+
+// assign returns the statements assigning a copy of src of type t to dst. dst is zero
+// if zeroed is set, which spares assigning it nil when src is nil. depth is the depth
+// of the nested loops, which suffixes the names of their variables.
+func (c *copier) assign(t types.Type, dst, src string, depth int, zeroed bool) string {
+	if u, ok := t.Underlying().(*types.Struct); ok && !hasCloneMethod(t) && (c.needsCopy(t) || containsLock(t)) {
+		return c.assignStruct(t, u, dst, src, depth, zeroed)
+	}
+	if expr, ok := c.expr(t, src); ok {
+		return fmt.Sprintf("%s = %s\n", dst, expr)
+	}
+
+	b := new(strings.Builder)
+	if u, ok := t.Underlying().(*types.Array); ok {
+		// the elements of dst are the elements of src until they are copied
+		i := fmt.Sprintf("i%d", depth)
+		fmt.Fprintf(b, "%s = %s\n", dst, src)
+		fmt.Fprintf(b, "for %s := range %s {\n", i, src)
+		b.WriteString(c.assign(u.Elem(), dst+"["+i+"]", src+"["+i+"]", depth+1, false))
+		b.WriteString("}\n")
+		return b.String()
+	}
+
+	if zeroed {
+		fmt.Fprintf(b, "if %s != nil {\n", src)
+	} else {
+		fmt.Fprintf(b, "if %s == nil {\n%s = nil\n} else {\n", src, dst)
+	}
+	if hasCloneMethod(t) {
+		fmt.Fprintf(b, "%s = %s.%s()\n}\n", dst, src, cloneMethod)
+		return b.String()
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		fmt.Fprintf(b, "%s = new(%s)\n", dst, c.params.TypeString(u.Elem()))
+		b.WriteString(c.assign(u.Elem(), "*"+dst, "*"+src, depth, true))
+	case *types.Slice:
+		i, v := fmt.Sprintf("i%d", depth), fmt.Sprintf("v%d", depth)
+		fmt.Fprintf(b, "%s = make(%s, len(%s))\n", dst, c.params.TypeString(t), src)
+		if containsLock(u.Elem()) {
+			// ranging over the values would copy their locks
+			fmt.Fprintf(b, "for %s := range %s {\n", i, src)
+			v = src + "[" + i + "]"
+		} else {
+			fmt.Fprintf(b, "for %s, %s := range %s {\n", i, v, src)
+		}
+		b.WriteString(c.assign(u.Elem(), dst+"["+i+"]", v, depth+1, true))
+		b.WriteString("}\n")
+	case *types.Map:
+		k, v, e := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth), fmt.Sprintf("e%d", depth)
+		fmt.Fprintf(b, "%s = make(%s, len(%s))\n", dst, c.params.TypeString(t), src)
+		fmt.Fprintf(b, "for %s, %s := range %s {\n", k, v, src)
+		if expr, ok := c.expr(u.Elem(), v); ok {
+			fmt.Fprintf(b, "%s[%s] = %s\n", dst, k, expr)
+		} else {
+			fmt.Fprintf(b, "var %s %s\n", e, c.params.TypeString(u.Elem()))
+			b.WriteString(c.assign(u.Elem(), e, v, depth+1, true))
+			fmt.Fprintf(b, "%s[%s] = %s\n", dst, k, e)
+		}
+		b.WriteString("}\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// assignStruct returns the statements assigning a copy of src of the struct type t to
+// dst, like assign. Values of the types of sync/atomic are copied by their methods,
+// and named structs by the helper copying their type.
+func (c *copier) assignStruct(t types.Type, u *types.Struct, dst, src string, depth int, zeroed bool) string {
+	switch {
+	case isAtomicType(t):
+		return fmt.Sprintf("%s.Store(%s.Load())\n", dst, src)
+	case types.Implements(types.NewPointer(t), lockerType):
+		// the copy gets a new lock
+		return ""
+	}
+	for _, copying := range c.copying {
+		if types.Identical(copying, t) {
+			return fmt.Sprintf("%s = %s\n", dst, src)
+		}
+	}
+	if named, ok := t.(*types.Named); ok {
+		method := c.helper(named, u)
+		if method == "" {
+			return ""
+		}
+		return fmt.Sprintf("%s.%s(%s, %s)\n", c.params.Receiver, method, address(dst), address(src))
+	}
+	return c.assignFields(t, u, dst, src, depth, zeroed)
+}
+
+// helper returns the name of the method copying the named struct t, adding it to the
+// helpers of c the first time t is copied, or "" if t has nothing to copy.
+func (c *copier) helper(t *types.Named, u *types.Struct) string {
+	for _, h := range c.helpers {
+		if types.Identical(h.typ, t) {
+			return h.Method
+		}
+	}
+
+	h := &copyHelper{genParameters: c.params, Method: c.helperName(t), Type: c.params.TypeString(t), typ: t}
+	i := len(c.helpers)
+	c.helpers = append(c.helpers, h)
+	// the fields of dst are not zero, since the helper copies to any struct
+	h.Body = strings.TrimSuffix(c.assignFields(t, u, "*dst", "*src", 0, false), "\n")
+	if h.Body == "" {
+		// t is being copied while its body is built, so no helper calls it yet
+		c.helpers = append(c.helpers[:i], c.helpers[i+1:]...)
+		return ""
+	}
+	return h.Method
+}
+
+// helperName returns the name of the method copying t: copy followed by the name of
+// t, and the lowest number from 2 with which it is unique if it is taken by another
+// helper, or by a field or method of the wrapped struct, which it would shadow.
+func (c *copier) helperName(t *types.Named) string {
+	name := "copy" + makeExportable(t.Obj().Name())
+	taken := func(name string) bool {
+		for _, h := range c.helpers {
+			if h.Method == name {
+				return true
+			}
+		}
+		if c.st == nil || c.st.Type == nil {
+			return false
+		}
+		obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(c.st.Type), true, c.pkg.Types, name)
+		return obj != nil
+	}
+	if !taken(name) {
+		return name
+	}
+	for i := 2; ; i++ {
+		if unique := name + strconv.Itoa(i); !taken(unique) {
+			return unique
+		}
+	}
+}
+
+// address returns the address of the addressable expression x, such as the pointer p
+// for *p.
+func address(x string) string {
+	if strings.HasPrefix(x, "*") {
+		return x[1:]
+	}
+	return "&" + x
+}
+
+// assignFields returns the statements assigning a copy of src of the struct type t to
+// dst, like assign, by assigning src and then copying the fields sharing memory, or by
+// copying the fields one by one if t contains a lock.
+func (c *copier) assignFields(t types.Type, u *types.Struct, dst, src string, depth int, zeroed bool) string {
+	c.copying = append(c.copying, t)
+	defer func() { c.copying = c.copying[:len(c.copying)-1] }()
+
+	b := new(strings.Builder)
+	byField := containsLock(t)
+	if !byField {
+		// the fields of dst are the fields of src until they are copied
+		fmt.Fprintf(b, "%s = %s\n", dst, src)
+		zeroed = false
+	}
+	for i := 0; i < u.NumFields(); i++ {
+		field := u.Field(i)
+		if field.Name() == "_" || isInaccessible(c.pkg, field.Name(), field.Pkg()) {
+			continue
+		}
+		ft := field.Type()
+		fdst, fsrc := selector(dst, field.Name()), selector(src, field.Name())
+		switch {
+		case c.needsCopy(ft) || containsLock(ft):
+			b.WriteString(c.assign(ft, fdst, fsrc, depth, zeroed))
+		case byField:
+			fmt.Fprintf(b, "%s = %s\n", fdst, fsrc)
+		}
+	}
+	return b.String()
+}
+
+// selector returns the selector of the field name of the struct x, which may be
+// a dereferenced pointer such as *p.
+func selector(x, name string) string {
+	x = strings.TrimPrefix(x, "*")
+	if strings.HasPrefix(x, "*") {
+		x = "(" + x + ")"
+	}
+	return x + "." + name
+}
+
+// needsCopy reports whether values of t share memory which copying accessors copy.
+// The fields of structs which the generated code cannot access are not copied, so
+// values of types such as time.Time are returned as they are.
+func (c *copier) needsCopy(t types.Type) bool {
+	if hasCloneMethod(t) {
+		return true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice, *types.Map:
+		return true
+	case *types.Pointer:
+		return !containsLock(u.Elem())
+	case *types.Array:
+		return c.needsCopy(u.Elem())
+	case *types.Struct:
+		if isAtomicType(t) {
+			return false
+		}
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			if field.Name() == "_" || isInaccessible(c.pkg, field.Name(), field.Pkg()) {
+				continue
+			}
+			if c.needsCopy(field.Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasCloneMethod reports whether t has a Clone method without parameters returning
+// a value of type t, including on the pointer of an addressable value of t.
+func hasCloneMethod(t types.Type) bool {
+	if _, ok := t.(*types.Named); !ok {
+		if ptr, ok := t.(*types.Pointer); !ok || !isNamed(ptr.Elem()) {
+			return false
+		}
+	}
+	methods := types.NewMethodSet(t)
+	if _, ok := t.(*types.Pointer); !ok {
+		methods = types.NewMethodSet(types.NewPointer(t))
+	}
+	sel := methods.Lookup(nil, cloneMethod)
+	if sel == nil {
+		return false
+	}
+	sig, ok := sel.Type().(*types.Signature)
+	return ok && sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), t)
+}
+
+// isAtomicType reports whether t is a type of sync/atomic with Load and Store methods,
+// such as atomic.Int64.
+func isAtomicType(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != atomicPkgPath {
+		return false
+	}
+	methods := types.NewMethodSet(types.NewPointer(t))
+	return methods.Lookup(named.Obj().Pkg(), "Load") != nil && methods.Lookup(named.Obj().Pkg(), "Store") != nil
+}
+
+func isNamed(t types.Type) bool {
+	_, ok := t.(*types.Named)
+	return ok
+}
+
+// isNillable reports whether values of t may be nil and so cannot be cloned by a method.
+func isNillable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface:
+		return true
+	}
+	return false
+}
+
+// containsLock reports whether values of t contain a lock, such as a sync.Mutex,
+// which must not be copied.
+func containsLock(t types.Type) bool {
+	return containsLockSeen(t, make(map[types.Type]bool))
+}
+
+func containsLockSeen(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		if types.Implements(types.NewPointer(t), lockerType) {
+			return true
+		}
+		for i := 0; i < u.NumFields(); i++ {
+			if containsLockSeen(u.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	case *types.Array:
+		return containsLockSeen(u.Elem(), seen)
+	}
+	return false
+}
+
+// cloneStatements returns the statements of the Clone method of the wrapper of st
+// copying the fields of st into clone like copying setters do, including the fields
+// of its embedded structs. A struct holding fields with atomic accessors is copied
+// field by field, loading them atomically.
+func (g *generator) cloneStatements(c *copier, pkg *Package, st *Struct, params *genParameters) ([]string, error) {
+	loads, err := g.atomicLoads(pkg, st, params)
+	if err != nil {
+		return nil, err
+	}
+	if len(loads) > 0 {
+		return c.cloneFields(st.Name, st.Type, loads), nil
+	}
+	// st is copied in place, which spares Clone a helper copying it
+	u := st.Type.Underlying().(*types.Struct)
+	return []string{strings.TrimSuffix(c.assignFields(st.Type, u, "clone."+st.Name, params.Receiver+"."+st.Name, 0, true), "\n")}, nil
+}
+
+// cloneFields returns the statements copying the fields of the struct type t at path
+// into clone one by one, loading the fields with atomic accessors with loads, by
+// path. The embedded structs holding such fields are copied field by field as well.
+func (c *copier) cloneFields(path string, t types.Type, loads map[string]string) []string {
+	u := t.Underlying().(*types.Struct)
+	var stmts []string
+	for i := 0; i < u.NumFields(); i++ {
+		field := u.Field(i)
+		name := path + "." + field.Name()
+		dst, src := "clone."+name, c.params.Receiver+"."+name
+		switch {
+		case loads[name] != "":
+			stmts = append(stmts, fmt.Sprintf("%s = %s", dst, loads[name]))
+		case field.Name() == "_" || isInaccessible(c.pkg, field.Name(), field.Pkg()):
+		case field.Embedded() && hasPathPrefix(loads, name):
+			stmts = append(stmts, c.cloneFields(name, field.Type(), loads)...)
+		default:
+			if stmt := c.copyIn(field.Type(), dst, src, true); stmt != "" {
+				stmts = append(stmts, stmt)
+			}
+		}
+	}
+	return stmts
+}
+
+// hasPathPrefix reports whether a path of loads is in the struct at path.
+func hasPathPrefix(loads map[string]string, path string) bool {
+	for name := range loads {
+		if strings.HasPrefix(name, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// atomicLoads returns the expressions loading the fields of st with atomic accessors
+// like their getters do, by path from the wrapper such as Tester.hits. The types of
+// sync/atomic are left out, since they are copied by their methods.
+func (g *generator) atomicLoads(pkg *Package, st *Struct, params *genParameters) (map[string]string, error) {
+	loads := make(map[string]string)
+	for _, field := range st.Fields {
+		if !g.hasAccessors(field) || !field.Tag.Atomic || isAtomicType(field.Type) {
+			continue
+		}
+		fieldParams := g.setupParameters(pkg, st, field, params)
+		if _, err := g.setupAtomic(field, fieldParams); err != nil {
+			return nil, err
+		}
+		load, err := g.atomicLoad(fieldParams)
+		if err != nil {
+			return nil, err
+		}
+		loads[fieldParams.Selector] = load
+		params.imports.addImport(stdPackage(atomicPkgPath))
+		if fieldParams.AtomicCast {
+			params.imports.addImport(stdPackage("unsafe"))
+		}
+	}
+	return loads, nil
+}
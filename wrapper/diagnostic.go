@@ -0,0 +1,245 @@
+package wrapper
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Codes of the diagnostics, telling the kind of problem they report.
+const (
+	CodeError             = "error"
+	CodeMissingType       = "missing-type"
+	CodeMalformedTag      = "malformed-tag"
+	CodeMethodCollision   = "method-collision"
+	CodeUnexportedType    = "unexported-type"
+	CodeUnexportedField   = "unexported-field"
+	CodeInvalidLock       = "invalid-lock"
+	CodeInvalidReceiver   = "invalid-receiver"
+	CodeUnsupportedAtomic = "unsupported-atomic"
+)
+
+// Diagnostic is a problem found in the source of a package, which prevents
+// generating its wrappers.
+type Diagnostic struct {
+	Pos     token.Position // invalid if the problem is not found at a position of the source
+	Code    string
+	Message string
+}
+
+func newDiagnostic(pos token.Position, code, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{Pos: pos, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (d *Diagnostic) Error() string {
+	if !d.Pos.IsValid() {
+		return d.Message
+	}
+	return d.Pos.String() + ": " + d.Message
+}
+
+// Diagnostics is a list of diagnostics reported as a single error.
+type Diagnostics []*Diagnostic
+
+func (ds Diagnostics) Error() string {
+	messages := make([]string, len(ds))
+	for i, d := range ds {
+		messages[i] = d.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// err returns ds as an error, or nil if ds is empty.
+func (ds Diagnostics) err() error {
+	if len(ds) == 0 {
+		return nil
+	}
+	return ds
+}
+
+// AsDiagnostics returns the diagnostics of err, or nil if err is nil. An error
+// which holds no diagnostic is returned as a diagnostic without position.
+func AsDiagnostics(err error) Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var ds Diagnostics
+	if errors.As(err, &ds) {
+		return ds
+	}
+	var d *Diagnostic
+	if errors.As(err, &d) {
+		return Diagnostics{d}
+	}
+	return Diagnostics{{Code: CodeError, Message: err.Error()}}
+}
+
+// packagePos returns the position of the package clause of the first file of pkg,
+// where problems of the package as a whole are reported.
+func packagePos(pkg *Package) token.Position {
+	if len(pkg.Syntax) == 0 {
+		return token.Position{}
+	}
+	return pkg.Fset.Position(pkg.Syntax[0].Name.Pos())
+}
+
+// validate checks the wrapper of st in pkg, and returns the problems which would
+// make the generated code fail to compile.
+func (g *generator) validate(pkg *Package, st *Struct) Diagnostics {
+	var ds Diagnostics
+	for _, field := range st.Fields {
+		if field.TagError != nil {
+			ds = append(ds, newDiagnostic(field.Pos, CodeMalformedTag, "malformed wrapper tag of field %s: %s", field.Name, field.TagError))
+		}
+	}
+
+	ds = append(ds, g.checkFieldAccess(pkg, st)...)
+	if d := g.checkReceiver(st); d != nil {
+		ds = append(ds, d)
+	}
+
+	if g.changeHooks && !g.pointer {
+		ds = append(ds, newDiagnostic(st.Pos, CodeError, "change hooks of %s require pointer receivers, which share the registered hooks; use -pointer", st.Name))
+	}
+	if g.tracksChanges() && !g.pointer {
+		ds = append(ds, newDiagnostic(st.Pos, CodeError, "change tracking of %s requires pointer receivers, which share the changed fields; use -pointer", st.Name))
+	}
+
+	if _, err := g.setupLocks(st); err != nil {
+		ds = append(ds, AsDiagnostics(err)...)
+	}
+	_, collisions := g.planMethods(st)
+	ds = append(ds, collisions...)
+
+	// types are named as in the generated code, whose imports they would add
+	imps := newImports(pkg)
+	g.reserveNames(st, imps)
+	params := &genParameters{imports: imps, src: pkg}
+	if st.pkg != nil {
+		params.src = st.pkg
+	}
+	for _, field := range st.Fields {
+		if !g.hasAccessors(field) {
+			continue
+		}
+		if t := unexportedType(pkg.Types, field.Type); t != nil {
+			ds = append(ds, newDiagnostic(field.Pos, CodeUnexportedType,
+				"field %s has type %s, which refers to the unexported type %s of package %s",
+				field.Name, params.TypeString(field.Type), t.Name(), t.Pkg().Path()))
+		}
+	}
+
+	return ds
+}
+
+// checkReceiver reports the receiver of the wrapper of st if the generated methods
+// would not compile with its name, which is the name of one of their locals or of
+// a package they refer to.
+func (g *generator) checkReceiver(st *Struct) *Diagnostic {
+	name := g.receiverName(st.Name)
+	if contains(methodLocals, name) || (g.reader || g.writer) && contains(ioLocals, name) {
+		return newDiagnostic(st.Pos, CodeInvalidReceiver, "receiver %s of %s clashes with a local variable of the generated methods; use -receiver", name, st.Name)
+	}
+	for _, imp := range g.fixedImports() {
+		if imp.Name == name {
+			return newDiagnostic(st.Pos, CodeInvalidReceiver, "receiver %s of %s clashes with the package %s of the generated methods; use -receiver", name, st.Name, imp.PkgPath)
+		}
+	}
+	return nil
+}
+
+// checkFieldAccess reports the fields of st with accessors which the wrapper in pkg
+// cannot access, because they or an embedded field on their path are unexported in
+// another package, such as the package of a struct of another package.
+func (g *generator) checkFieldAccess(pkg *Package, st *Struct) Diagnostics {
+	var ds Diagnostics
+	for _, field := range st.Fields {
+		if !g.hasAccessors(field) {
+			continue
+		}
+		name, declPkg := field.Name, field.pkg
+		for _, e := range field.Path {
+			if isInaccessible(pkg, e.Name, e.pkg) {
+				name, declPkg = e.Name, e.pkg
+				break
+			}
+		}
+		if isInaccessible(pkg, name, declPkg) {
+			pos := field.Pos
+			if field.promoted() {
+				// the declaration of another package may have no column, or no position
+				pos = field.Path[0].Pos
+			}
+			ds = append(ds, newDiagnostic(pos, CodeUnexportedField,
+				"field %s of %s is unexported in package %s, so the wrapper in package %s cannot access it",
+				name, st.qualifiedName(), declPkg.Name(), pkg.Name))
+		}
+	}
+	return ds
+}
+
+// isInaccessible reports whether the field name declared in declPkg is unexported
+// in another package than pkg.
+func isInaccessible(pkg *Package, name string, declPkg *types.Package) bool {
+	return declPkg != nil && declPkg.Path() != pkg.PkgPath && !token.IsExported(name)
+}
+
+// unexportedType returns the name of an unexported named type or alias of another
+// package than pkg referenced by t, which generated code cannot refer to, or nil if
+// there is none.
+func unexportedType(pkg *types.Package, t types.Type) *types.TypeName {
+	if obj, args, ok := alias(t); ok {
+		if p := obj.Pkg(); p != nil && p != pkg && !obj.Exported() {
+			return obj
+		}
+		return unexportedTypeArg(pkg, args)
+	}
+	switch t := t.(type) {
+	case *types.Named:
+		if p := t.Obj().Pkg(); p != nil && p != pkg && !t.Obj().Exported() {
+			return t.Obj()
+		}
+		return unexportedTypeArg(pkg, t.TypeArgs())
+	case *types.Pointer:
+		return unexportedType(pkg, t.Elem())
+	case *types.Slice:
+		return unexportedType(pkg, t.Elem())
+	case *types.Array:
+		return unexportedType(pkg, t.Elem())
+	case *types.Chan:
+		return unexportedType(pkg, t.Elem())
+	case *types.Map:
+		if u := unexportedType(pkg, t.Key()); u != nil {
+			return u
+		}
+		return unexportedType(pkg, t.Elem())
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{t.Params(), t.Results()} {
+			for i := 0; i < tuple.Len(); i++ {
+				if u := unexportedType(pkg, tuple.At(i).Type()); u != nil {
+					return u
+				}
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if u := unexportedType(pkg, t.Field(i).Type()); u != nil {
+				return u
+			}
+		}
+	}
+	return nil
+}
+
+// unexportedTypeArg returns the name of the first unexported type of another package
+// than pkg referenced by args, or nil if there is none.
+func unexportedTypeArg(pkg *types.Package, args *types.TypeList) *types.TypeName {
+	for i := 0; i < args.Len(); i++ {
+		if u := unexportedType(pkg, args.At(i)); u != nil {
+			return u
+		}
+	}
+	return nil
+}
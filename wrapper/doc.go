@@ -0,0 +1,19 @@
+// Package wrapper generates wrapper types for structs, with accessors for the fields
+// tagged `wrapper`, the interface of the wrapper and encoding methods. It is the library
+// behind the type-wrapper command, and takes the same settings as functional options:
+//
+//	pkg, err := wrapper.ParsePackage("path/to/target")
+//	if err != nil {
+//		return err
+//	}
+//	files, err := wrapper.GenerateFiles(pkg, wrapper.Types("MyStruct"), wrapper.Interface("I{{.Type}}"))
+//	if err != nil {
+//		return err
+//	}
+//	for path, content := range files {
+//		// write or compare content
+//	}
+//
+// Generate writes the files to an afero.Fs instead, and Check and DryRun compare them
+// with the files on disk. Problems of the source are returned as Diagnostics.
+package wrapper
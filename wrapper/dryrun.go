@@ -0,0 +1,77 @@
+package wrapper
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// FileStatus is what writing a generated file would do to the file on disk.
+type FileStatus string
+
+const (
+	FileCreated   FileStatus = "create"
+	FileChanged   FileStatus = "change"
+	FileUnchanged FileStatus = "unchanged"
+)
+
+// FileChange describes a file that Generate would write.
+type FileChange struct {
+	Path    string
+	Status  FileStatus
+	Methods int // number of methods declared in the generated file
+}
+
+// DryRun generates the wrappers of pkg in memory without writing them, and
+// reports how each generated file compares with the file in fs.
+func DryRun(fs afero.Fs, pkg *Package, options ...Option) ([]*FileChange, error) {
+	generated, paths, err := generateInMemory(pkg, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*FileChange, 0, len(paths))
+	for _, path := range paths {
+		want := generated[path]
+		methods, err := countMethods(path, want)
+		if err != nil {
+			return nil, err
+		}
+
+		status := FileChanged
+		got, err := afero.ReadFile(fs, path)
+		switch {
+		case os.IsNotExist(err):
+			status = FileCreated
+		case err != nil:
+			return nil, err
+		case bytes.Equal(got, want):
+			status = FileUnchanged
+		}
+
+		changes = append(changes, &FileChange{Path: path, Status: status, Methods: methods})
+	}
+
+	return changes, nil
+}
+
+// countMethods returns the number of methods declared in the source src.
+func countMethods(path string, src []byte) (int, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, src, parser.SkipObjectResolution)
+	if err != nil {
+		return 0, err
+	}
+
+	var methods int
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil {
+			methods++
+		}
+	}
+
+	return methods, nil
+}
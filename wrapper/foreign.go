@@ -0,0 +1,85 @@
+package wrapper
+
+import (
+	"errors"
+	"go/token"
+	"strings"
+)
+
+// splitQualifiedName splits a type name qualified by the import path of its package,
+// such as github.com/org/lib.Config, and reports whether name is qualified.
+func splitQualifiedName(name string) (path, typeName string, ok bool) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 {
+		return "", name, false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// IsQualifiedTypeName reports whether name is the name of a type qualified by the
+// import path of its package, such as github.com/org/lib.Config.
+func IsQualifiedTypeName(name string) bool {
+	_, _, ok := splitQualifiedName(name)
+	return ok
+}
+
+// lookupTargetStruct returns the struct named name to generate a wrapper for in pkg:
+// a struct of pkg, or of another package if name is qualified by its import path.
+func (pkg *Package) lookupTargetStruct(name string) (*Struct, error) {
+	path, typeName, ok := splitQualifiedName(name)
+	if !ok || path == pkg.PkgPath {
+		return pkg.lookupStruct(typeName), nil
+	}
+	return pkg.lookupForeignStruct(path, typeName)
+}
+
+// qualifiedName returns the name of st as written in the package of its wrapper,
+// such as lib.Config for a struct of another package.
+func (st *Struct) qualifiedName() string {
+	if st.pkg == nil {
+		return st.Name
+	}
+	return st.pkg.Name + "." + st.Name
+}
+
+// lookupForeignStruct returns the struct named name of the package of import path, loaded
+// in the module of pkg, to be wrapped in pkg; it returns nil if there is no such struct.
+// Structs of other packages usually have no wrapper tags, so a struct without any gets
+// a getter and a setter for each exported field.
+func (pkg *Package) lookupForeignStruct(path, name string) (*Struct, error) {
+	fp, ok := pkg.foreign[path]
+	if !ok {
+		pkgs, err := loadPackages(pkg.Dir, path)
+		if err != nil {
+			return nil, err
+		}
+		fp = pkgs[0]
+		if len(fp.Errors) > 0 && len(fp.Structs) == 0 {
+			return nil, errors.New(fp.Errors[0].Error())
+		}
+		if pkg.foreign == nil {
+			pkg.foreign = make(map[string]*Package)
+		}
+		pkg.foreign[path] = fp
+	}
+
+	st := fp.lookupStruct(name)
+	if st == nil {
+		return nil, nil
+	}
+
+	foreign := *st
+	foreign.pkg = fp
+	if !st.hasTag() {
+		foreign.Fields = make([]*Field, len(st.Fields))
+		for i, field := range st.Fields {
+			f := *field
+			if token.IsExported(field.Name) {
+				f.Tag = &Tag{Getter: new(string), Setter: new(string)}
+			}
+			foreign.Fields[i] = &f
+		}
+	}
+
+	return &foreign, nil
+}
@@ -0,0 +1,1018 @@
+package wrapper
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/afero"
+	"golang.org/x/tools/go/packages"
+)
+
+type generator struct {
+	types         []string
+	all           bool
+	wrapperType   string
+	interfaceName string
+	output        string
+	receiver      string
+	lock          string
+	reader        bool
+	writer        bool
+	registry      bool
+	pointer       bool
+	codecName     string
+	codecTag      string
+	codec         *codec
+	collisions    string
+	templatesDir  string
+	templates     *template.Template
+	embeddedPtrs  bool
+	defensiveCopy bool
+	changeHooks   bool
+	trackChanges  bool
+	changedJSON   bool
+	stdout        io.Writer
+	typeOptions   map[string][]Option
+}
+
+type genParameters struct {
+	Receiver      string
+	Struct        string
+	StructType    string // type of the struct, qualified if it is declared in another package
+	WrapperStruct string
+	Interface     string
+	Field         string
+	Selector      string      // selector of the field from the receiver, such as Tester.Audit.CreatedAt
+	NilGuards     []*nilGuard // embedded pointers on the path to a promoted field, outermost first
+	GetterMethod  string
+	SetterMethod  string
+	Type          string
+	ZeroValue     string // used only when generating getter
+	Lock          string
+	Reader        bool
+	Writer        bool
+	Codec         *codec            // used only when generating reader and writer
+	Pointer       bool              // generate methods with pointer receivers
+	TypeParams    string            // type parameter list of a generic struct, such as [T any]
+	TypeArgs      string            // type arguments of a generic struct, such as [T]
+	RLock         string            // method locking Lock for reading
+	RUnlock       string            // method unlocking Lock for reading
+	Locks         []*lockParameters // every lock of the struct
+
+	// used only when generating atomic accessors
+	Atomic               bool
+	AtomicFunc           string // suffix of sync/atomic functions; empty for fields of sync/atomic types
+	AtomicCast           bool   // the field is a pointer stored as unsafe.Pointer
+	AtomicBool           bool   // the field is a bool updated by the methods of the "atomic-bool" template
+	AtomicAdd            bool
+	SwapMethod           string
+	CompareAndSwapMethod string
+	AddMethod            string
+
+	// used only when generating accessors
+	FieldType types.Type // type of the field
+	Tag       *Tag       // wrapper tag of the field
+	CopyOut   string     // statements of a copying getter returning the copy of the field
+	CopyIn    string     // statements of a copying setter assigning the copy of val to the field
+
+	// used only when generating Clone with -defensive-copy
+	Clone           bool
+	CloneStatements []string // statements copying the struct into clone
+
+	// used only with -change-hooks
+	Hooks      bool
+	HookMethod string            // method registering the hooks of the field, if its setter calls hooks
+	FieldHooks []*hookParameters // methods registering the hooks of every field, used only for the wrapper
+
+	// used only with -track-changes
+	TrackChanges bool
+	ChangedJSON  bool                // generate ChangedJSON
+	ChangeWords  int                 // number of words of the bitset of the changed fields
+	Changes      []*changeParameters // tracked fields, used only for the wrapper
+	Change       *changeParameters   // the tracked field, nil if its setter does not mark it changed
+
+	Methods []string // used only when generating interface: methods of the accessors
+
+	imports *imports // imports of the file of the wrapper, naming the packages of types
+	src     *Package // package declaring the struct, whose files may alias the packages of types
+}
+
+// lockParameters describes a lock field and the methods that lock it for reading.
+type lockParameters struct {
+	Field   string
+	RLock   string
+	RUnlock string
+}
+
+// nilGuard is an embedded pointer which accessors of promoted fields check for nil.
+type nilGuard struct {
+	Selector string // selector of the pointer from the receiver
+	Type     string // type the pointer points to
+}
+
+// namePattern is the data passed to wrapper and interface naming patterns.
+type namePattern struct {
+	Type string
+}
+
+// file holds the declarations generated into a single output file.
+type file struct {
+	path     string
+	imports  *imports
+	wrappers []string
+}
+
+const defaultWrapperPattern = "{{.Type}}Wrapper"
+
+// stdoutOutput is the output file name writing the generated code to stdout.
+const stdoutOutput = "-"
+
+func newGenerator(options ...Option) *generator {
+	g := &generator{stdout: os.Stdout}
+	for _, opt := range options {
+		opt(g)
+	}
+
+	return g
+}
+
+// Generate generates wrapper files and methods for the target types.
+func Generate(fs afero.Fs, pkg *Package, options ...Option) error {
+	g := newGenerator(options...)
+	files, err := g.generateFiles(pkg)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		w := newWriter(fs, f.path)
+		if f.path == stdoutOutput {
+			w = newStdoutWriter(g.stdout)
+		}
+		if err := w.write(pkg.Name, f.imports.importStrings(), f.wrappers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GenerateFiles generates the wrappers of the target types like Generate, and returns
+// the contents of the files by path instead of writing them. The code Generate would
+// write to stdout is keyed by "-".
+func GenerateFiles(pkg *Package, options ...Option) (map[string][]byte, error) {
+	g := newGenerator(options...)
+	files, err := g.generateFiles(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make(map[string][]byte, len(files))
+	for _, f := range files {
+		content, err := newWriter(nil, f.path).render(pkg.Name, f.imports.importStrings(), f.wrappers)
+		if err != nil {
+			return nil, err
+		}
+		contents[f.path] = content
+	}
+
+	return contents, nil
+}
+
+// generateFiles generates the declarations of the files of the target types of pkg.
+func (g *generator) generateFiles(pkg *Package) ([]*file, error) {
+	if err := g.setup(); err != nil {
+		return nil, err
+	}
+
+	structs, err := g.targetStructs(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	// report every problem of the target structs before generating any of them
+	var diagnostics Diagnostics
+	for _, st := range structs {
+		tg, err := g.forType(st.Name)
+		if err != nil {
+			return nil, err
+		}
+		diagnostics = append(diagnostics, tg.validate(pkg, st)...)
+	}
+	if err := diagnostics.err(); err != nil {
+		return nil, err
+	}
+
+	// the registry is written with the codec of the first generator registering wrappers
+	var registryGen *generator
+	if g.registry {
+		registryGen = g
+	}
+
+	files := make([]*file, 0, len(structs))
+	filesByPath := make(map[string]*file, len(structs))
+	for _, st := range structs {
+		tg, err := g.forType(st.Name)
+		if err != nil {
+			return nil, err
+		}
+		if tg.registry && registryGen == nil {
+			registryGen = tg
+		}
+
+		path := tg.outputFilePath(pkg.Dir, st.Name)
+		f, ok := filesByPath[path]
+		if !ok {
+			f = &file{path: path, imports: newImports(pkg)}
+			filesByPath[path] = f
+			files = append(files, f)
+		}
+
+		if err := tg.generateType(pkg, st, f); err != nil {
+			return nil, err
+		}
+	}
+
+	if registryGen != nil {
+		registry, err := registryGen.generateRegistry()
+		if err != nil {
+			return nil, err
+		}
+		path := g.registryFilePath(pkg.Dir)
+		f, ok := filesByPath[path]
+		if !ok {
+			f = &file{path: path, imports: newImports(pkg)}
+			files = append(files, f)
+		}
+		f.wrappers = append(f.wrappers, registry)
+		f.imports.addImports(registryGen.codec.imports)
+		f.imports.addImport(stdPackage("fmt"))
+	}
+
+	return files, nil
+}
+
+// setup checks the collision policy of g, and loads its templates and resolves its codec.
+func (g *generator) setup() error {
+	if err := g.checkCollisionPolicy(); err != nil {
+		return err
+	}
+	var err error
+	if g.templates, err = loadTemplates(g.templatesDir); err != nil {
+		return err
+	}
+	return g.setupCodec()
+}
+
+// setupCodec resolves the codec of g.
+func (g *generator) setupCodec() error {
+	var err error
+	if g.codec, err = newCodec(g.codecName, g.codecTag); err != nil {
+		return err
+	}
+	if (g.writer || g.registry) && !g.codec.canUnmarshal() {
+		return fmt.Errorf("codec %s has no Unmarshal function to decode with", g.codecName)
+	}
+	return nil
+}
+
+// forType returns the generator of the type named typeName, which is g with the
+// options of the type applied.
+func (g *generator) forType(typeName string) (*generator, error) {
+	options := g.typeOptions[typeName]
+	if len(options) == 0 {
+		return g, nil
+	}
+
+	tg := *g
+	tg.typeOptions = nil
+	for _, opt := range options {
+		opt(&tg)
+	}
+	if err := tg.setup(); err != nil {
+		return nil, fmt.Errorf("type %s: %w", typeName, err)
+	}
+
+	return &tg, nil
+}
+
+// targetStructs returns the structs of pkg to generate wrappers for.
+func (g *generator) targetStructs(pkg *Package) ([]*Struct, error) {
+	var structs []*Struct
+	if g.all {
+		structs = make([]*Struct, 0, len(pkg.Structs))
+		for _, st := range pkg.Structs {
+			if st.hasTag() {
+				structs = append(structs, st)
+			}
+		}
+	} else {
+		structs = make([]*Struct, 0, len(g.types))
+		var missing Diagnostics
+		for _, typ := range g.types {
+			st, err := pkg.lookupTargetStruct(typ)
+			if err != nil {
+				return nil, err
+			}
+			if st == nil {
+				where := "package " + pkg.Name
+				if path, _, ok := splitQualifiedName(typ); ok {
+					where = path
+				}
+				missing = append(missing, newDiagnostic(packagePos(pkg), CodeMissingType, "struct type %s not found in %s", typ, where))
+				continue
+			}
+			structs = append(structs, st)
+		}
+		if len(missing) > 0 {
+			return nil, missing
+		}
+	}
+
+	if len(g.types) > 1 || g.all {
+		if err := g.checkNamePatterns(structs); err != nil {
+			return nil, err
+		}
+	}
+
+	return structs, nil
+}
+
+// checkNamePatterns reports an error when the wrapper or interface name
+// would be the same for every generated type. A name set by the options of
+// a type is not shared with the other types.
+func (g *generator) checkNamePatterns(structs []*Struct) error {
+	for _, st := range structs {
+		tg, err := g.forType(st.Name)
+		if err != nil {
+			return err
+		}
+		if tg.wrapperType == g.wrapperType && g.wrapperType != "" && !isPattern(g.wrapperType) {
+			return fmt.Errorf("wrapper name %q must be a pattern such as %s when generating several types", g.wrapperType, defaultWrapperPattern)
+		}
+		if tg.interfaceName == g.interfaceName && g.interfaceName != "" && !isPattern(g.interfaceName) {
+			return fmt.Errorf("interface name %q must be a pattern such as I{{.Type}} when generating several types", g.interfaceName)
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) generateType(pkg *Package, st *Struct, f *file) error {
+	wrappers := make([]string, 0)
+	ifaces := make([]string, 0)
+
+	g.reserveNames(st, f.imports)
+	typeParams, err := g.setupTypeParameters(pkg, st, f.imports)
+	if err != nil {
+		return err
+	}
+	plan, collisions := g.planMethods(st)
+	if len(collisions) > 0 {
+		return collisions
+	}
+	if g.changeHooks {
+		g.setupHooks(st, plan, typeParams)
+		f.imports.addImport(stdPackage("sync"))
+	}
+	var changes map[*Field]*changeParameters
+	if g.tracksChanges() {
+		changes = g.setupChanges(st, plan, typeParams)
+		f.imports.addImport(stdPackage("sync"))
+	}
+
+	structType, err := g.generateStruct(typeParams)
+	if err != nil {
+		return err
+	}
+	wrappers = append(wrappers, structType)
+
+	// the accessors and Clone share the helpers copying the named structs
+	c := &copier{pkg: pkg, st: st, params: typeParams}
+	for _, field := range st.Fields {
+		if g.hasAccessors(field) && isAtomicBool(field) {
+			// Clone and ChangedJSON load the field even if -collisions skip drops its methods
+			params := g.setupParameters(pkg, st, field, typeParams)
+			if _, err := g.setupAtomic(field, params); err != nil {
+				return err
+			}
+			methods, err := g.generateAtomicBool(params)
+			if err != nil {
+				return err
+			}
+			wrappers = append(wrappers, methods)
+			f.imports.addImport(stdPackage(atomicPkgPath))
+			f.imports.addImport(stdPackage("unsafe"))
+		}
+		if !g.hasAccessors(field) || !plan[field].generates() {
+			// -collisions skip may drop every method of the field
+			continue
+		}
+
+		params := g.setupParameters(pkg, st, field, typeParams)
+		accessorType := field.Type
+		if field.Tag.Atomic {
+			if accessorType, err = g.setupAtomic(field, params); err != nil {
+				return err
+			}
+			if params.AtomicFunc != "" {
+				f.imports.addImport(stdPackage(atomicPkgPath))
+			}
+			if params.AtomicCast {
+				f.imports.addImport(stdPackage("unsafe"))
+			}
+		}
+		plan[field].apply(params)
+		params.Hooks = g.changeHooks
+		if change := changes[field]; change != nil {
+			params.Change = change
+			change.Selector, change.NilGuards = params.Selector, params.NilGuards
+			if params.Atomic {
+				change.Load = params.call("Load")
+			}
+		}
+		if (g.defensiveCopy || field.Tag.Copy) && !params.Atomic {
+			params.CopyOut = c.copyOut(field.Type, params.Receiver+"."+params.Selector)
+			params.CopyIn = c.copyIn(field.Type, params.Receiver+"."+params.Selector, "val", false)
+		}
+
+		if field.Tag.Getter != nil && params.GetterMethod != "" {
+			getter, err := g.generateGetter(params)
+			if err != nil {
+				return err
+			}
+			wrappers = append(wrappers, getter)
+
+			iface, err := g.generateGetterInterface(params)
+			if err != nil {
+				return err
+			}
+			ifaces = append(ifaces, iface)
+		}
+		if field.Tag.Setter != nil && (params.SetterMethod != "" || params.Atomic) {
+			setter, err := g.generateSetter(params)
+			if err != nil {
+				return err
+			}
+			wrappers = append(wrappers, setter)
+
+			iface, err := g.generateSetterInterface(params)
+			if err != nil {
+				return err
+			}
+			ifaces = append(ifaces, iface)
+		}
+
+		f.imports.addTypeImports(params.src, accessorType)
+	}
+
+	if g.reader {
+		readerFunc, err := g.generateReader(typeParams)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, readerFunc)
+		f.imports.addImports(g.codec.imports)
+		f.imports.addImport(stdPackage("io"))
+	}
+
+	if g.writer {
+		writerFunc, err := g.generateWriter(typeParams)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, writerFunc)
+		f.imports.addImports(g.codec.imports)
+		f.imports.addImport(stdPackage("fmt"))
+		f.imports.addImport(stdPackage("errors"))
+		f.imports.addImport(stdPackage("io"))
+		if g.codec.Streams() {
+			f.imports.addImport(stdPackage("bytes"))
+		}
+	}
+
+	if g.changeHooks {
+		hooks, err := g.execute("hooks", typeParams)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, hooks)
+	}
+
+	if g.tracksChanges() {
+		track, err := g.execute("track", typeParams)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, track)
+		if g.changedJSON {
+			f.imports.addImport(stdPackage("encoding/json"))
+		}
+	}
+
+	if g.defensiveCopy {
+		typeParams.Clone = true
+		if typeParams.CloneStatements, err = g.cloneStatements(c, pkg, st, typeParams); err != nil {
+			return err
+		}
+		clone, err := g.execute("clone", typeParams)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, clone)
+	}
+	for _, helper := range c.helpers {
+		method, err := g.execute("copy", helper)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, method)
+	}
+
+	if g.registry {
+		if err := g.checkRegistry(st); err != nil {
+			return err
+		}
+		registration, err := g.generateRegistration(typeParams)
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, registration)
+	}
+
+	iface, err := g.generateInterface(typeParams, ifaces)
+	if err != nil {
+		return err
+	}
+	f.wrappers = append(f.wrappers, iface)
+	f.wrappers = append(f.wrappers, wrappers...)
+
+	return nil
+}
+
+// reserveNames reserves the names the templates refer to in the wrapper of st, which
+// are the packages they import, its receiver and its type parameters, so that the
+// packages of field types are imported under other names.
+func (g *generator) reserveNames(st *Struct, imps *imports) {
+	for _, imp := range g.fixedImports() {
+		imps.reserve(imp.Name, imp.PkgPath)
+	}
+	imps.reserve(g.receiverName(st.Name), "")
+	for i := 0; i < st.TypeParams.Len(); i++ {
+		imps.reserve(st.TypeParams.At(i).Obj().Name(), "")
+	}
+}
+
+// fixedImports returns the packages the templates may import, which are referred to by
+// their own name.
+func (g *generator) fixedImports() []*packages.Package {
+	return append([]*packages.Package{
+		stdPackage("bytes"), stdPackage("errors"), stdPackage("fmt"), stdPackage("io"), stdPackage(atomicPkgPath),
+		stdPackage("unsafe"), stdPackage("slices"), stdPackage("maps"), stdPackage("sync"), stdPackage("encoding/json"),
+	}, g.codec.imports...)
+}
+
+func (g *generator) outputFilePath(dir, typeName string) string {
+	output := g.output
+	if output == stdoutOutput {
+		return stdoutOutput
+	}
+	if output == "" {
+		// Use snake_case name of type as output file if output file is not specified.
+		// type TestStruct will be test_struct_wrapper.go
+		output = fmt.Sprintf("%s_wrapper.go", snakeCase(typeName))
+	}
+
+	return filepath.Join(dir, output)
+}
+
+func (g *generator) generateSetter(
+	params *genParameters,
+) (string, error) {
+	if params.Atomic {
+		return g.generateAtomicSetter(params)
+	}
+	return g.execute("setter", params)
+}
+
+func (g *generator) generateGetter(
+	params *genParameters,
+) (string, error) {
+	if params.Atomic {
+		return g.generateAtomicGetter(params)
+	}
+	return g.execute("getter", params)
+}
+
+func (g *generator) generateGetterInterface(
+	params *genParameters,
+) (string, error) {
+	if params.Interface == "" {
+		return "", nil
+	}
+	return g.execute("getter-interface", params)
+}
+
+func (g *generator) generateSetterInterface(
+	params *genParameters,
+) (string, error) {
+	if params.Atomic {
+		return g.generateAtomicSetterInterface(params)
+	}
+	if params.Interface == "" {
+		return "", nil
+	}
+	return g.execute("setter-interface", params)
+}
+
+func (g *generator) generateReader(
+	params *genParameters,
+) (string, error) {
+	return g.execute("reader", params)
+}
+
+func (g *generator) generateWriter(
+	params *genParameters,
+) (string, error) {
+	return g.execute("writer", params)
+}
+
+func (g *generator) generateStruct(
+	params *genParameters,
+) (string, error) {
+	return g.execute("struct", params)
+}
+
+// generateInterface generates the interface of the wrapper, declaring methods,
+// the methods of the accessors, followed by the methods of -reader and -writer.
+func (g *generator) generateInterface(params *genParameters, methods []string) (string, error) {
+	if params.Interface == "" {
+		return "", nil
+	}
+
+	params.Methods = make([]string, 0, len(methods))
+	for _, method := range methods {
+		for _, line := range strings.Split(method, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				params.Methods = append(params.Methods, line)
+			}
+		}
+	}
+	return g.execute("interface", params)
+}
+
+func (g *generator) setupParameters(
+	pkg *Package,
+	st *Struct,
+	field *Field,
+	typeParams *genParameters,
+) *genParameters {
+	// the type of atomic accessors is set by setupAtomic
+	var typeName string
+	if !field.Tag.Atomic {
+		typeName = typeParams.TypeString(field.Type)
+	}
+	getter, setter := g.methodNames(field)
+
+	selector := st.Name
+	var guards []*nilGuard
+	for _, e := range field.Path {
+		selector += "." + e.Name
+		if e.Pointer {
+			elem := e.Type.(*types.Pointer).Elem()
+			guards = append(guards, &nilGuard{Selector: selector, Type: typeParams.TypeString(elem)})
+		}
+	}
+	selector += "." + field.Name
+
+	lock := &lockParameters{}
+	if name := g.lockName(field); name != "" {
+		for _, l := range typeParams.Locks {
+			if l.Field == name {
+				lock = l
+				break
+			}
+		}
+	}
+
+	return &genParameters{
+		Receiver:      typeParams.Receiver,
+		Struct:        st.Name,
+		StructType:    typeParams.StructType,
+		WrapperStruct: typeParams.WrapperStruct,
+		Field:         field.Name,
+		Selector:      selector,
+		NilGuards:     guards,
+		GetterMethod:  getter,
+		SetterMethod:  setter,
+		Type:          typeName,
+		ZeroValue:     g.zeroValue(field.Type, typeName),
+		Lock:          lock.Field,
+		Reader:        g.reader,
+		Writer:        g.writer,
+		Interface:     typeParams.Interface,
+		Pointer:       g.pointer,
+		TypeParams:    typeParams.TypeParams,
+		TypeArgs:      typeParams.TypeArgs,
+		RLock:         lock.RLock,
+		RUnlock:       lock.RUnlock,
+		FieldType:     field.Type,
+		Tag:           field.Tag,
+		imports:       typeParams.imports,
+		src:           typeParams.src,
+	}
+}
+
+func (g *generator) setupTypeParameters(
+	pkg *Package,
+	st *Struct,
+	imps *imports,
+) (*genParameters, error) {
+	wrapperType := g.wrapperType
+	if wrapperType == "" {
+		wrapperType = defaultWrapperPattern
+	}
+	wrapperType, err := expandName(wrapperType, st.Name)
+	if err != nil {
+		return nil, err
+	}
+	interfaceName, err := expandName(g.interfaceName, st.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// types of the fields are imported by the package declaring the struct
+	src, structType := pkg, st.Name
+	if st.pkg != nil {
+		src = st.pkg
+		structType = imps.addPackage(src, src.Types) + "." + st.Name
+	}
+	typeParams, typeArgs := g.typeParameters(imps, src, st.TypeParams)
+
+	locks, err := g.setupLocks(st)
+	if err != nil {
+		return nil, err
+	}
+	lock := &lockParameters{}
+	if g.lock != "" {
+		lock = locks[0]
+	}
+
+	return &genParameters{
+		Receiver:      g.receiverName(st.Name),
+		Struct:        st.Name,
+		StructType:    structType,
+		WrapperStruct: wrapperType,
+		Reader:        g.reader,
+		Writer:        g.writer,
+		Codec:         g.codec,
+		Interface:     interfaceName,
+		Pointer:       g.pointer,
+		TypeParams:    typeParams,
+		TypeArgs:      typeArgs,
+		Lock:          lock.Field,
+		RLock:         lock.RLock,
+		RUnlock:       lock.RUnlock,
+		Locks:         locks,
+		imports:       imps,
+		src:           src,
+	}, nil
+}
+
+// typeParameters returns the type parameter list with constraints, such as
+// [K comparable, V any], and the matching type arguments, such as [K, V].
+// Both are empty for non-generic structs.
+func (g *generator) typeParameters(imps *imports, src *Package, tparams *types.TypeParamList) (params, args string) {
+	if tparams.Len() == 0 {
+		return "", ""
+	}
+
+	paramList := make([]string, tparams.Len())
+	argList := make([]string, tparams.Len())
+	for i := 0; i < tparams.Len(); i++ {
+		tparam := tparams.At(i)
+		paramList[i] = tparam.Obj().Name() + " " + imps.typeString(src, tparam.Constraint())
+		argList[i] = tparam.Obj().Name()
+	}
+
+	return "[" + strings.Join(paramList, ", ") + "]", "[" + strings.Join(argList, ", ") + "]"
+}
+
+// isPattern reports whether name is a naming pattern such as {{.Type}}Wrapper.
+func isPattern(name string) bool {
+	return strings.Contains(name, "{{")
+}
+
+// expandName executes the naming pattern name for the type typeName.
+// Names that are not patterns are returned as they are.
+func expandName(name, typeName string) (string, error) {
+	if !isPattern(name) {
+		return name, nil
+	}
+
+	t, err := template.New("name").Parse(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid naming pattern %q: %w", name, err)
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, namePattern{Type: typeName}); err != nil {
+		return "", fmt.Errorf("invalid naming pattern %q: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// setupLocks returns the type-level lock followed by the locks set by tags
+// of st, in the order of declaration. It reports the problems of every lock.
+func (g *generator) setupLocks(st *Struct) ([]*lockParameters, error) {
+	names := make([]string, 0)
+	positions := make(map[string]token.Position) // where each lock is named
+	if g.lock != "" {
+		names = append(names, g.lock)
+		positions[g.lock] = st.Pos
+	}
+	for _, field := range st.Fields {
+		if !g.hasAccessors(field) {
+			continue
+		}
+		if name := g.lockName(field); name != "" && !contains(names, name) {
+			names = append(names, name)
+			positions[name] = field.Pos
+		}
+	}
+
+	locks := make([]*lockParameters, len(names))
+	var diagnostics Diagnostics
+	for i, name := range names {
+		rlock, runlock, err := g.readLockMethods(st, name, positions[name])
+		if err != nil {
+			diagnostics = append(diagnostics, err)
+			continue
+		}
+		locks[i] = &lockParameters{Field: name, RLock: rlock, RUnlock: runlock}
+	}
+	if err := diagnostics.err(); err != nil {
+		return nil, err
+	}
+
+	return locks, nil
+}
+
+// hasAccessors reports whether accessors are generated for field, which is a field with
+// a wrapper tag, not promoted through an embedded pointer unless -embedded-pointers is set.
+func (g *generator) hasAccessors(field *Field) bool {
+	return field.Tag != nil && (g.embeddedPtrs || !field.throughPointer())
+}
+
+// lockName returns the name of the lock field guarding field, which is
+// the lock of its tag or the type-level lock if the tag sets none.
+func (g *generator) lockName(field *Field) string {
+	if field.Tag == nil || field.Tag.Lock == nil || *field.Tag.Lock == "" {
+		return g.lock
+	}
+	if *field.Tag.Lock == ignoreTag {
+		return ""
+	}
+	return *field.Tag.Lock
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	lockerType   = methodsInterface("Lock", "Unlock")
+	rwLockerType = methodsInterface("Lock", "Unlock", "RLock", "RUnlock")
+)
+
+// methodsInterface returns an interface of methods without parameters and results.
+func methodsInterface(names ...string) *types.Interface {
+	sig := types.NewSignatureType(nil, nil, nil, nil, nil, false)
+	methods := make([]*types.Func, len(names))
+	for i, name := range names {
+		methods[i] = types.NewFunc(token.NoPos, nil, name, sig)
+	}
+	return types.NewInterfaceType(methods, nil).Complete()
+}
+
+// readLockMethods checks the lock field of st and returns the methods that lock
+// it for reading: RLock and RUnlock for read-write locks such as sync.RWMutex,
+// and Lock and Unlock for any other sync.Locker. A missing lock field is reported
+// at pos, where the lock is named.
+func (g *generator) readLockMethods(st *Struct, lock string, pos token.Position) (rlock, runlock string, err *Diagnostic) {
+	field := st.lookupField(lock)
+	if field == nil {
+		return "", "", newDiagnostic(pos, CodeInvalidLock, "lock field %q not found in %s", lock, st.Name)
+	}
+	if st.pkg != nil && !token.IsExported(lock) {
+		return "", "", newDiagnostic(pos, CodeInvalidLock, "lock field %q of %s is unexported in package %s", lock, st.qualifiedName(), st.pkg.Name)
+	}
+
+	typ := field.Type
+	copied := false
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+	default:
+		// The lock field is addressable, so methods declared on its pointer can be called.
+		typ = types.NewPointer(typ)
+		copied = !g.pointer
+	}
+
+	switch {
+	case types.Implements(typ, rwLockerType):
+		rlock, runlock = "RLock", "RUnlock"
+	case types.Implements(typ, lockerType):
+		rlock, runlock = "Lock", "Unlock"
+	default:
+		return "", "", newDiagnostic(field.Pos, CodeInvalidLock, "lock field %q of %s has type %s, which does not implement sync.Locker", lock, st.Name, field.Type)
+	}
+
+	if copied {
+		return "", "", newDiagnostic(field.Pos, CodeInvalidLock, "lock field %q of %s would be copied by value receivers; use pointer receivers", lock, st.Name)
+	}
+
+	return rlock, runlock, nil
+}
+
+func (g *generator) receiverName(structName string) string {
+	if g.receiver != "" {
+		// Do nothing if receiver name specified in args.
+		return g.receiver
+	}
+
+	// Use the first letter of struct as receiver if receiver name is not specified.
+	return strings.ToLower(string(structName[0]))
+}
+
+func makeExportable(name string) string {
+	return strings.ToUpper(name[0:1]) + name[1:]
+}
+
+func (g *generator) methodNames(field *Field) (getter, setter string) {
+	if getterName := field.Tag.Getter; getterName != nil && *getterName != "" {
+		getter = *getterName
+	} else {
+		getter = makeExportable(field.Name)
+	}
+
+	if setterName := field.Tag.Setter; setterName != nil && *setterName != "" {
+		setter = *setterName
+	} else {
+		setter = "Set" + makeExportable(field.Name)
+	}
+
+	return getter, setter
+}
+
+func (g *generator) zeroValue(t types.Type, typeString string) string {
+	switch t := t.(type) {
+	case *types.Pointer:
+		return "nil"
+	case *types.Array:
+		return typeString + "{}"
+	case *types.Slice:
+		return "nil"
+	case *types.Chan:
+		return "nil"
+	case *types.Interface:
+		return "nil"
+	case *types.Map:
+		return "nil"
+	case *types.Signature:
+		return "nil"
+	case *types.Struct:
+		return typeString + "{}"
+	case *types.Basic:
+		info := types.Typ[t.Kind()].Info()
+		switch {
+		case types.IsNumeric&info != 0:
+			return "0"
+		case types.IsBoolean&info != 0:
+			return "false"
+		case types.IsString&info != 0:
+			return `""`
+		}
+	case *types.TypeParam:
+		return "*new(" + typeString + ")"
+	case *types.Named:
+		if types.Identical(t, types.Universe.Lookup("error").Type()) {
+			return "nil"
+		}
+
+		return g.zeroValue(t.Underlying(), typeString)
+	}
+
+	return "nil"
+}
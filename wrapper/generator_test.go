@@ -0,0 +1,81 @@
+package wrapper_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/Marble-Technologies/type-wrapper/wrapper"
+)
+
+func TestGenerateFiles(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := wrapper.ParsePackage("../cmd/testdata/getter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := []wrapper.Option{wrapper.Types("Tester"), wrapper.Interface("ITester"), wrapper.Reader(true)}
+
+	files, err := wrapper.GenerateFiles(pkg, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(pkg.Dir, "tester_wrapper.go")
+	if len(files) != 1 || files[path] == nil {
+		t.Fatalf("want the contents of %s, got files %v", path, keys(files))
+	}
+
+	fs := afero.NewMemMapFs()
+	if err := wrapper.Generate(fs, pkg, options...); err != nil {
+		t.Fatal(err)
+	}
+	written, err := afero.ReadFile(fs, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(files[path], written) {
+		t.Errorf("contents differ from the file written by Generate:\n%s\nwritten:\n%s", files[path], written)
+	}
+}
+
+func TestGenerateFilesDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := wrapper.ParsePackage("../cmd/testdata/diagnostics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = wrapper.GenerateFiles(pkg, wrapper.Types("Collision"), wrapper.Reader(true))
+	diagnostics := wrapper.AsDiagnostics(err)
+	if len(diagnostics) != 2 || diagnostics[0].Code != wrapper.CodeMethodCollision || diagnostics[0].Pos.Line != 17 {
+		t.Errorf("want 2 method collisions from line 17, got %v", err)
+	}
+}
+
+func TestGenerateFilesDiagnosticTypes(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := wrapper.ParsePackage("../cmd/testdata/diagnostics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = wrapper.GenerateFiles(pkg, wrapper.Types("AtomicDuration"), wrapper.Pointer(true))
+	diagnostics := wrapper.AsDiagnostics(err)
+	if len(diagnostics) != 1 || diagnostics[0].Code != wrapper.CodeUnsupportedAtomic || !strings.Contains(diagnostics[0].Message, "stdtime.Duration") {
+		t.Errorf("want an unsupported atomic field of type stdtime.Duration, got %v", err)
+	}
+}
+
+func keys(files map[string][]byte) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	return paths
+}
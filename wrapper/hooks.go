@@ -0,0 +1,77 @@
+package wrapper
+
+import (
+	"go/types"
+	"strings"
+)
+
+// changeHooksMethod is the name of the method registering the hooks called by every
+// setter, which the wrappers generated with -change-hooks have.
+const changeHooksMethod = "OnChange"
+
+// hookParameters describes the method registering the hooks of a field.
+type hookParameters struct {
+	Method  string   // name of the method, such as OnNameChange
+	Setters []string // names of the setters calling the hooks
+	Field   string
+	Type    string // type of the values of the field, which is the type of the values passed to the hooks
+}
+
+// Callers returns the setters calling the hooks followed by the verb, such as
+// "SetName calls", as written in the doc comment of the method.
+func (h *hookParameters) Callers() string {
+	if len(h.Setters) == 1 {
+		return h.Setters[0] + " calls"
+	}
+	last := len(h.Setters) - 1
+	return strings.Join(h.Setters[:last], ", ") + " and " + h.Setters[last] + " call"
+}
+
+// setupHooks sets the parameters of the wrapper of st used to declare and register the
+// hooks of its fields, which are the fields with a setter planned in plan.
+func (g *generator) setupHooks(st *Struct, plan map[*Field]fieldMethods, params *genParameters) {
+	params.Hooks = true
+	for _, field := range st.Fields {
+		methods, ok := plan[field]
+		if !ok || len(methods.setters()) == 0 || methods[hookMethod] == "" {
+			continue
+		}
+		t := field.Type
+		if field.Tag.Atomic {
+			t = atomicValueType(t)
+		}
+		params.FieldHooks = append(params.FieldHooks, &hookParameters{
+			Method:  methods[hookMethod],
+			Setters: methods.setters(),
+			Field:   field.Name,
+			Type:    params.TypeString(t),
+		})
+	}
+}
+
+// hookCall is the data of the "call-hooks" template, which calls the hooks of the
+// field with old and the new value New.
+type hookCall struct {
+	*genParameters
+	New string
+}
+
+// HookCall returns the data to call the hooks of the field with old and the new value
+// named value.
+func (p *genParameters) HookCall(value string) *hookCall {
+	return &hookCall{genParameters: p, New: value}
+}
+
+// atomicValueType returns the type of the values of a field of type t with atomic
+// accessors: the type loaded by a type of sync/atomic, or t itself.
+func atomicValueType(t types.Type) types.Type {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != atomicPkgPath {
+		return t
+	}
+	load := types.NewMethodSet(types.NewPointer(t)).Lookup(named.Obj().Pkg(), "Load")
+	if load == nil {
+		return t
+	}
+	return load.Type().(*types.Signature).Results().At(0).Type()
+}
@@ -0,0 +1,299 @@
+package wrapper
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// methodLocals are the identifiers declared by the built-in templates in the bodies of
+// the methods of the wrapper, which the receiver must not be named after.
+var methodLocals = []string{
+	"buf", "changeHooks", "changed", "changes", "child", "clone", "current", "data", "dec", "delta",
+	"dst", "encoded", "err", "header", "hook", "hooks", "name", "new", "next", "object", "off", "ok",
+	"old", "one", "parent", "shift", "src", "swapped", "syntaxErr", "val", "value", "word",
+}
+
+// ioLocals are the parameters and locals of the io methods of -reader and -writer,
+// named after those of package io, which the receiver must not be named after either.
+var ioLocals = []string{"n", "p", "w"}
+
+// templateLocals are the identifiers declared by the built-in templates in the bodies
+// of the generated methods and functions, which would shadow imports of the same name.
+var templateLocals = append(append([]string{"arg", "e", "wrapper"}, methodLocals...), ioLocals...)
+
+// imports is the set of packages imported by a generated file, with the name each
+// package is referred to by in the file. Packages of field types get their name in
+// their source file when it is free, so that an aliased import keeps its alias,
+// and a collision-free alias otherwise.
+type imports struct {
+	pkg      *types.Package      // package of the file, which is not imported
+	list     []*packages.Package // imported packages, with Name set to their name in the file
+	names    map[string]string   // name in the file by import path
+	reserved map[string]string   // import path by name, for names which cannot be used by other packages
+}
+
+func newImports(pkg *Package) *imports {
+	imps := &imports{
+		pkg:      pkg.Types,
+		names:    make(map[string]string),
+		reserved: make(map[string]string),
+	}
+	for _, name := range pkg.Types.Scope().Names() {
+		imps.reserve(name, "")
+	}
+	for _, name := range templateLocals {
+		imps.reserve(name, "")
+	}
+	return imps
+}
+
+// reserve keeps name for the package of path, or for a declaration of the file if
+// path is empty, so that other packages are imported under another name.
+func (imps *imports) reserve(name, path string) {
+	if _, ok := imps.reserved[name]; !ok {
+		imps.reserved[name] = path
+	}
+}
+
+// addImport adds imp to the imports under its name, unless it is already there.
+// Packages added this way are referred to by the templates under their own name,
+// which should be reserved for them before adding packages of field types.
+func (imps *imports) addImport(imp *packages.Package) {
+	if imp == nil {
+		return
+	}
+	if _, ok := imps.names[imp.PkgPath]; ok {
+		return
+	}
+	imps.names[imp.PkgPath] = imp.Name
+	imps.list = append(imps.list, imp)
+}
+
+// addImports adds the packages of list to the imports under their name.
+func (imps *imports) addImports(list []*packages.Package) {
+	for _, imp := range list {
+		imps.addImport(imp)
+	}
+}
+
+// addPackage adds p to the imports unless it is the package of the file, and returns
+// its name in the file. src is the package whose source files may alias p.
+func (imps *imports) addPackage(src *Package, p *types.Package) string {
+	if p == nil || p.Path() == imps.pkg.Path() {
+		return ""
+	}
+	if name, ok := imps.names[p.Path()]; ok {
+		return name
+	}
+
+	name := imps.freeName(p, src.importAliases()[p.Path()])
+	imps.names[p.Path()] = name
+	imps.list = append(imps.list, &packages.Package{ID: p.Path(), Name: name, PkgPath: p.Path()})
+	return name
+}
+
+// freeName returns a name for p which is not used by another package or declaration:
+// the first free one of its aliases in its source files, its name, its name prefixed
+// by the parent directory of its path, and its name suffixed by a number.
+func (imps *imports) freeName(p *types.Package, aliases map[string]bool) string {
+	candidates := make([]string, 0, len(aliases)+2)
+	for alias := range aliases {
+		candidates = append(candidates, alias)
+	}
+	sort.Strings(candidates)
+	candidates = append(candidates, p.Name())
+	if parent := identifier(path.Base(path.Dir(p.Path()))); parent != "" {
+		candidates = append(candidates, parent+p.Name())
+	}
+
+	for _, name := range candidates {
+		if imps.isFree(name, p.Path()) {
+			return name
+		}
+	}
+	for i := 2; ; i++ {
+		if name := p.Name() + strconv.Itoa(i); imps.isFree(name, p.Path()) {
+			return name
+		}
+	}
+}
+
+// isFree reports whether name can be the name of the package of path in the file.
+func (imps *imports) isFree(name, path string) bool {
+	if reserved, ok := imps.reserved[name]; ok && reserved != path {
+		return false
+	}
+	for _, imp := range imps.list {
+		if imp.Name == name {
+			return false
+		}
+	}
+	return true
+}
+
+// identifier returns s without the characters which cannot be in an identifier,
+// lower-cased, or an empty string if it does not start with a letter.
+func identifier(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		return ""
+	}
+	return s
+}
+
+// addTypeImports adds the packages of every named type referenced by t to the imports.
+// It walks the whole type, such as the key and element types of maps, the parameters
+// and results of functions, and the type arguments of instantiated generic types.
+func (imps *imports) addTypeImports(src *Package, t types.Type) {
+	for _, p := range typePackages(t) {
+		imps.addPackage(src, p)
+	}
+}
+
+// typePackages returns the packages of the named types referenced by t, in the order
+// they are written.
+func typePackages(t types.Type) []*types.Package {
+	var pkgs []*types.Package
+	seen := make(map[types.Type]bool)
+
+	var walk func(t types.Type)
+	walk = func(t types.Type) {
+		if t == nil || seen[t] {
+			return
+		}
+		seen[t] = true
+
+		if obj, args, ok := alias(t); ok {
+			if p := obj.Pkg(); p != nil {
+				pkgs = append(pkgs, p)
+			}
+			for i := 0; i < args.Len(); i++ {
+				walk(args.At(i))
+			}
+			return
+		}
+
+		switch t := t.(type) {
+		case *types.Named:
+			if p := t.Obj().Pkg(); p != nil {
+				pkgs = append(pkgs, p)
+			}
+			for i := 0; i < t.TypeArgs().Len(); i++ {
+				walk(t.TypeArgs().At(i))
+			}
+		case *types.Pointer:
+			walk(t.Elem())
+		case *types.Slice:
+			walk(t.Elem())
+		case *types.Array:
+			walk(t.Elem())
+		case *types.Chan:
+			walk(t.Elem())
+		case *types.Map:
+			walk(t.Key())
+			walk(t.Elem())
+		case *types.Signature:
+			walkTuple(t.Params(), walk)
+			walkTuple(t.Results(), walk)
+		case *types.Struct:
+			for i := 0; i < t.NumFields(); i++ {
+				walk(t.Field(i).Type())
+			}
+		case *types.Interface:
+			for i := 0; i < t.NumEmbeddeds(); i++ {
+				walk(t.EmbeddedType(i))
+			}
+			for i := 0; i < t.NumExplicitMethods(); i++ {
+				walk(t.ExplicitMethod(i).Type())
+			}
+		case *types.Union:
+			for i := 0; i < t.Len(); i++ {
+				walk(t.Term(i).Type())
+			}
+		case *types.TypeParam:
+			// a type parameter is written by its name, declared with its constraint
+		}
+	}
+	walk(t)
+
+	return pkgs
+}
+
+func walkTuple(tuple *types.Tuple, walk func(types.Type)) {
+	for i := 0; i < tuple.Len(); i++ {
+		walk(tuple.At(i).Type())
+	}
+}
+
+// typeString returns t as written in the file, adding the packages it refers to to
+// the imports. src is the package whose source files may alias them.
+func (imps *imports) typeString(src *Package, t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		return imps.addPackage(src, p)
+	})
+}
+
+// importStrings returns the import specs of the file, sorted by name.
+func (imps *imports) importStrings() []string {
+	// Ensure imports are same order as previous if there are no declaration changes.
+	list := make([]*packages.Package, len(imps.list))
+	copy(list, imps.list)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	specs := make([]string, len(list))
+	for i, pkg := range list {
+		if pkg.Name == filepath.Base(pkg.PkgPath) {
+			specs[i] = strconv.Quote(pkg.PkgPath)
+		} else {
+			specs[i] = fmt.Sprintf("%s %q", pkg.Name, pkg.PkgPath)
+		}
+	}
+
+	return specs
+}
+
+// stdPackage returns the standard library package of path.
+func stdPackage(path string) *packages.Package {
+	return &packages.Package{ID: path, Name: filepath.Base(path), PkgPath: path}
+}
+
+// importAliases returns the names given to each imported package by the import
+// declarations of the files of pkg, by import path. Dot and blank imports are left out.
+func (pkg *Package) importAliases() map[string]map[string]bool {
+	if pkg.aliases != nil {
+		return pkg.aliases
+	}
+
+	pkg.aliases = make(map[string]map[string]bool)
+	for _, f := range pkg.Syntax {
+		for _, spec := range f.Imports {
+			if spec.Name == nil || spec.Name.Name == "." || spec.Name.Name == "_" {
+				continue
+			}
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if pkg.aliases[path] == nil {
+				pkg.aliases[path] = make(map[string]bool)
+			}
+			pkg.aliases[path][spec.Name.Name] = true
+		}
+	}
+	return pkg.aliases
+}
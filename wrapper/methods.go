@@ -0,0 +1,235 @@
+package wrapper
+
+import (
+	"fmt"
+	"go/types"
+	"strconv"
+)
+
+// Policies resolving the collisions of the methods generated for fields.
+const (
+	// CollisionError reports each collision as a diagnostic; it is the default.
+	CollisionError = "error"
+	// CollisionSkip does not generate the colliding methods.
+	CollisionSkip = "skip"
+	// CollisionRename renames the colliding getters Get<Field>, and appends the lowest
+	// number from 2 making the name unique to the other methods.
+	CollisionRename = "rename"
+)
+
+// methodKind is the kind of a method generated for a field.
+type methodKind int
+
+const (
+	getterMethod methodKind = iota
+	setterMethod
+	swapMethod
+	compareAndSwapMethod
+	addMethod
+	hookMethod
+)
+
+// fieldMethods maps the kinds of the methods generated for a field to their
+// names; the name of a skipped method is empty.
+type fieldMethods map[methodKind]string
+
+// checkCollisionPolicy checks the collision policy of g.
+func (g *generator) checkCollisionPolicy() error {
+	switch g.collisions {
+	case "", CollisionError, CollisionSkip, CollisionRename:
+		return nil
+	}
+	return fmt.Errorf("unknown collision policy %q; use %s, %s or %s", g.collisions, CollisionError, CollisionSkip, CollisionRename)
+}
+
+// planMethods returns the names of the methods generated for the fields of st. A method
+// collides if it has the same name as another generated method, a field of the wrapper
+// or a method of st, which it would shadow. Collisions are resolved by the collision
+// policy of g, or returned as diagnostics if the policy is CollisionError.
+func (g *generator) planMethods(st *Struct) (map[*Field]fieldMethods, Diagnostics) {
+	// the fields of the wrapper, and the methods which are not generated from a field
+	declared := map[string]string{st.Name: "the embedded field " + st.Name}
+	if g.reader || g.writer {
+		declared["DataType"] = "the DataType field"
+	}
+	if g.reader {
+		for _, name := range []string{"Read", "Reset", "WriteTo", g.codec.MarshalMethod} {
+			declared[name] = "the method " + name + " of -reader"
+		}
+		for _, name := range []string{"readBuf", "readOff"} {
+			declared[name] = "the field " + name + " of -reader"
+		}
+	}
+	if g.writer {
+		for _, name := range []string{"Write", g.codec.UnmarshalMethod, makeUnexportable(g.codec.UnmarshalMethod)} {
+			declared[name] = "the method " + name + " of -writer"
+		}
+		declared["writeBuf"] = "the field writeBuf of -writer"
+	}
+	if g.changeHooks {
+		declared[changeHooksMethod] = "the method " + changeHooksMethod + " of -change-hooks"
+		for _, name := range []string{"hooksLock", makeUnexportable(changeHooksMethod)} {
+			declared[name] = "the field " + name + " of -change-hooks"
+		}
+	}
+	if g.tracksChanges() {
+		for _, name := range trackMethods {
+			declared[name] = "the method " + name + " of -track-changes"
+		}
+		for _, name := range []string{"changesLock", "changes"} {
+			declared[name] = "the field " + name + " of -track-changes"
+		}
+	}
+	if g.changedJSON {
+		declared[changedJSONMethod] = "the method " + changedJSONMethod + " of -changed-json"
+	}
+	if g.defensiveCopy {
+		declared[cloneMethod] = "the method " + cloneMethod + " of -defensive-copy"
+	}
+	for _, field := range st.Fields {
+		if g.hasAccessors(field) && isAtomicBool(field) {
+			word, load, swap, compareAndSwap := atomicBoolMethods(field.Name)
+			for _, name := range []string{word, load, swap, compareAndSwap} {
+				declared[name] = "the method " + name + " of the atomic accessors of field " + field.Name
+			}
+		}
+	}
+	if st.Type != nil {
+		methods := types.NewMethodSet(types.NewPointer(st.Type))
+		for i := 0; i < methods.Len(); i++ {
+			name := methods.At(i).Obj().Name()
+			if _, ok := declared[name]; !ok {
+				declared[name] = "the method " + name + " of " + st.Name + ", which it would shadow"
+			}
+		}
+	}
+
+	plan := make(map[*Field]fieldMethods)
+	var ds Diagnostics
+	for _, field := range st.Fields {
+		if !g.hasAccessors(field) {
+			continue
+		}
+
+		methods := g.fieldMethods(field)
+		for _, kind := range []methodKind{getterMethod, setterMethod, swapMethod, compareAndSwapMethod, addMethod, hookMethod} {
+			name, ok := methods[kind]
+			if !ok {
+				continue
+			}
+			if other, ok := collision(kind, name, declared); ok {
+				switch g.collisions {
+				case CollisionSkip:
+					methods[kind] = ""
+					continue
+				case CollisionRename:
+					if kind == getterMethod {
+						name = "Get" + makeExportable(field.Name)
+					}
+					if _, ok := collision(kind, name, declared); ok {
+						name = uniqueName(kind, name, declared)
+					}
+					methods[kind] = name
+				default:
+					ds = append(ds, newDiagnostic(field.Pos, CodeMethodCollision,
+						"method %s of field %s collides with %s", name, field.Name, other))
+					continue
+				}
+			}
+			declared[name] = "the method " + name + " of field " + field.Name
+			if kind == hookMethod {
+				hooks := makeUnexportable(name)
+				declared[hooks] = "the field " + hooks + " of the hooks of field " + field.Name
+			}
+		}
+		plan[field] = methods
+	}
+
+	return plan, ds
+}
+
+// fieldMethods returns the names of the methods generated for field before
+// resolving their collisions.
+func (g *generator) fieldMethods(field *Field) fieldMethods {
+	getter, setter := g.methodNames(field)
+	methods := make(fieldMethods)
+	if field.Tag.Getter != nil {
+		methods[getterMethod] = getter
+	}
+	if field.Tag.Setter != nil {
+		methods[setterMethod] = setter
+		if field.Tag.Atomic {
+			name := makeExportable(field.Name)
+			methods[swapMethod] = "Swap" + name
+			methods[compareAndSwapMethod] = "CompareAndSwap" + name
+			if isAtomicAddable(field.Type) {
+				methods[addMethod] = "Add" + name
+			}
+		}
+		if g.changeHooks {
+			methods[hookMethod] = "On" + makeExportable(field.Name) + "Change"
+		}
+	}
+	return methods
+}
+
+// collision returns what the method of kind named name collides with in declared, and
+// reports whether it collides. The method registering the hooks of a field collides
+// with the field of the wrapper keeping them as well, which is its unexported name.
+func collision(kind methodKind, name string, declared map[string]string) (string, bool) {
+	if other, ok := declared[name]; ok {
+		return other, true
+	}
+	if kind == hookMethod {
+		other, ok := declared[makeUnexportable(name)]
+		return other, ok
+	}
+	return "", false
+}
+
+// uniqueName returns name followed by the lowest number from 2 with which the method
+// of kind does not collide with declared.
+func uniqueName(kind methodKind, name string, declared map[string]string) string {
+	for i := 2; ; i++ {
+		unique := name + strconv.Itoa(i)
+		if _, ok := collision(kind, unique, declared); !ok {
+			return unique
+		}
+	}
+}
+
+// generates reports whether any accessor of the field is kept, so that the wrapper
+// refers to the type of the field.
+func (methods fieldMethods) generates() bool {
+	for kind, name := range methods {
+		if kind != hookMethod && name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// setters returns the names of the kept methods setting the field, which call its
+// hooks: the setter, followed by the atomic methods of fields tagged atomic.
+func (methods fieldMethods) setters() []string {
+	var names []string
+	for _, kind := range []methodKind{setterMethod, swapMethod, compareAndSwapMethod, addMethod} {
+		if name := methods[kind]; name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// apply sets the names of the methods of params to the planned ones. The hooks of
+// a field are registered only if one of its setters, which call them, is generated.
+func (methods fieldMethods) apply(params *genParameters) {
+	params.GetterMethod = methods[getterMethod]
+	params.SetterMethod = methods[setterMethod]
+	params.SwapMethod = methods[swapMethod]
+	params.CompareAndSwapMethod = methods[compareAndSwapMethod]
+	params.AddMethod = methods[addMethod]
+	if len(methods.setters()) > 0 {
+		params.HookMethod = methods[hookMethod]
+	}
+}
@@ -0,0 +1,177 @@
+package wrapper
+
+import "io"
+
+type Option func(*generator)
+
+// Type sets type name to genarator.
+func Type(typeName string) Option {
+	return Types(typeName)
+}
+
+// Types sets type names to genarator; a wrapper is generated for each type.
+func Types(typeNames ...string) Option {
+	return func(g *generator) {
+		g.types = typeNames
+	}
+}
+
+// All sets whether wrappers are generated for every struct with a wrapper tag.
+func All(all bool) Option {
+	return func(g *generator) {
+		g.all = all
+	}
+}
+
+// Output sets output file path to genarator; "-" writes the generated code to stdout.
+func Output(output string) Option {
+	return func(g *generator) {
+		g.output = output
+	}
+}
+
+// TypeOptions sets options applying only to the type named typeName, which
+// override the other options of the generator for this type.
+func TypeOptions(typeName string, options ...Option) Option {
+	return func(g *generator) {
+		if g.typeOptions == nil {
+			g.typeOptions = make(map[string][]Option)
+		}
+		g.typeOptions[typeName] = append(g.typeOptions[typeName], options...)
+	}
+}
+
+// Collisions sets the policy resolving the collisions of the methods generated
+// for fields: CollisionError, CollisionSkip or CollisionRename.
+func Collisions(policy string) Option {
+	return func(g *generator) {
+		g.collisions = policy
+	}
+}
+
+// Templates sets the directory of templates overriding the built-in ones; the file
+// name.tmpl overrides the template name, such as getter.tmpl.
+func Templates(dir string) Option {
+	return func(g *generator) {
+		g.templatesDir = dir
+	}
+}
+
+// Stdout sets the writer of the generated code when the output is "-"; default os.Stdout.
+func Stdout(w io.Writer) Option {
+	return func(g *generator) {
+		g.stdout = w
+	}
+}
+
+// Receiver sets receiver name to genarator.
+func Receiver(receiver string) Option {
+	return func(g *generator) {
+		g.receiver = receiver
+	}
+}
+
+// Lock sets lock field name to genarator.
+func Lock(lock string) Option {
+	return func(g *generator) {
+		g.lock = lock
+	}
+}
+
+// Implement io.Reader
+func Reader(reader bool) Option {
+	return func(g *generator) {
+		g.reader = reader
+	}
+}
+
+// Implement io.Writer
+func Writer(writer bool) Option {
+	return func(g *generator) {
+		g.writer = writer
+	}
+}
+
+// Registry sets whether wrappers are registered in the registry of the package,
+// which decodes payloads into the wrapper of their type.
+func Registry(registry bool) Option {
+	return func(g *generator) {
+		g.registry = registry
+	}
+}
+
+// Codec sets the codec used by reader and writer: json, xml, gob, or the
+// qualified name of a Marshal(any) ([]byte, error) function such as gopkg.in/yaml.v3.Marshal.
+func Codec(name string) Option {
+	return func(g *generator) {
+		g.codecName = name
+	}
+}
+
+// CodecTag sets the struct tag key of a custom codec; default the package name of the codec.
+func CodecTag(tag string) Option {
+	return func(g *generator) {
+		g.codecTag = tag
+	}
+}
+
+// Wrapper sets wrapper type name, or a naming pattern such as {{.Type}}Wrapper.
+func Wrapper(typ string) Option {
+	return func(g *generator) {
+		g.wrapperType = typ
+	}
+}
+
+// Interface sets interface name, or a naming pattern such as I{{.Type}}.
+func Interface(interfaceName string) Option {
+	return func(g *generator) {
+		g.interfaceName = interfaceName
+	}
+}
+
+// EmbeddedPointers sets whether accessors are generated for the tagged fields promoted
+// through embedded pointers, which check the pointers for nil.
+func EmbeddedPointers(embeddedPointers bool) Option {
+	return func(g *generator) {
+		g.embeddedPtrs = embeddedPointers
+	}
+}
+
+// DefensiveCopy sets whether every getter returns a copy of the values of slices, maps
+// and pointers, every setter stores a copy, and the wrapper has a Clone method.
+func DefensiveCopy(defensiveCopy bool) Option {
+	return func(g *generator) {
+		g.defensiveCopy = defensiveCopy
+	}
+}
+
+// ChangeHooks sets whether the wrapper has OnChange and per-field On<Field>Change methods
+// registering hooks, which the setters call after they set a field.
+func ChangeHooks(changeHooks bool) Option {
+	return func(g *generator) {
+		g.changeHooks = changeHooks
+	}
+}
+
+// TrackChanges sets whether setters mark their field changed in a bitset of the wrapper,
+// which has Changed, IsChanged and ClearChanges methods.
+func TrackChanges(trackChanges bool) Option {
+	return func(g *generator) {
+		g.trackChanges = trackChanges
+	}
+}
+
+// ChangedJSON sets whether the wrapper has a ChangedJSON method encoding the changed
+// fields by their json tag names; it implies TrackChanges.
+func ChangedJSON(changedJSON bool) Option {
+	return func(g *generator) {
+		g.changedJSON = changedJSON
+	}
+}
+
+// Pointer sets whether generated methods use pointer receivers.
+func Pointer(pointer bool) Option {
+	return func(g *generator) {
+		g.pointer = pointer
+	}
+}
@@ -0,0 +1,326 @@
+package wrapper
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	wrapperTag   = "wrapper"
+	ignoreTag    = "-"
+	tagKeyGetter = "getter"
+	tagKeySetter = "setter"
+	tagKeyLock   = "lock"
+	tagKeyAtomic = "atomic"
+	tagKeyCopy   = "copy"
+)
+
+const (
+	tagSep         = ","
+	tagKeyValueSep = ":"
+)
+
+// ParsePackage parses the specified directory's package.
+func ParsePackage(dir string) (*Package, error) {
+	pkgs, err := ParsePackages(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("error: %d packages found", len(pkgs))
+	}
+
+	return pkgs[0], nil
+}
+
+// ParsePackages loads the packages matching the go/packages patterns in one pass,
+// such as ./..., import paths or directories, and parses them.
+func ParsePackages(patterns ...string) ([]*Package, error) {
+	normalized := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		p, err := normalizePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = p
+	}
+
+	return loadPackages("", normalized...)
+}
+
+// loadPackages loads the packages matching patterns in the module of dir, or of the
+// current directory if dir is empty, and parses them.
+func loadPackages(dir string, patterns ...string) ([]*Package, error) {
+	const mode = packages.NeedName | packages.NeedFiles |
+		packages.NeedImports | packages.NeedTypes | packages.NeedSyntax
+
+	cfg := &packages.Config{
+		Mode:  mode,
+		Dir:   dir,
+		Tests: false,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("error: no packages found for %s", strings.Join(patterns, " "))
+	}
+
+	parsed := make([]*Package, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		var dir string // a package without Go files has no directory
+		if len(pkg.GoFiles) > 0 {
+			dir = filepath.Dir(pkg.GoFiles[0])
+		}
+
+		parsed = append(parsed, &Package{
+			Package: pkg,
+			Dir:     dir,
+			Structs: parseStructs(pkg),
+		})
+	}
+
+	return parsed, nil
+}
+
+// normalizePattern returns pattern with relative directories, such as
+// path/to/dir or path/to/dir/..., made absolute; go/packages would take
+// them for import paths.
+func normalizePattern(pattern string) (string, error) {
+	dir, recursive := strings.TrimSuffix(pattern, "/..."), strings.HasSuffix(pattern, "/...")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return pattern, nil
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if recursive {
+		return dir + "/...", nil
+	}
+	return dir, nil
+}
+
+func parseStructs(pkg *packages.Package) []*Struct {
+	scope := pkg.Types.Scope()
+	structs := make([]*Struct, 0, len(scope.Names()))
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		var tparams *types.TypeParamList
+		if named, ok := obj.Type().(*types.Named); ok {
+			tparams = named.TypeParams()
+		}
+
+		structs = append(structs, &Struct{
+			Name:       name,
+			Type:       obj.Type(),
+			Pos:        pkg.Fset.Position(obj.Pos()),
+			TypeParams: tparams,
+			Fields:     parseFields(pkg.Fset, st),
+		})
+	}
+
+	return structs
+}
+
+// parseFields returns the fields of st: the fields it declares, followed by the fields
+// promoted from its embedded structs, including through embedded pointers.
+func parseFields(fset *token.FileSet, st *types.Struct) []*Field {
+	fields := parseDeclaredFields(fset, st, nil)
+
+	// Promoted fields are found depth by depth as Go selectors are: a name declared
+	// at a lower depth hides the deeper ones, and a name declared more than once at
+	// the same depth is ambiguous and not promoted.
+	hidden := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		hidden[field.Name] = true
+	}
+	visited := make(map[types.Type]bool) // embedded types already descended into, ending embedding cycles
+	embedded := embeddedStructs(fields)
+	for len(embedded) > 0 {
+		var depth []*Field
+		count := make(map[string]int)
+		descended := make(map[types.Type]bool)
+		for _, e := range embedded {
+			if visited[e.typ] {
+				continue
+			}
+			descended[e.typ] = true
+			for _, field := range parseDeclaredFields(fset, e.st, e.path) {
+				depth = append(depth, field)
+				count[field.Name]++
+			}
+		}
+		for typ := range descended {
+			visited[typ] = true
+		}
+
+		var promoted []*Field
+		for _, field := range depth {
+			if !hidden[field.Name] && count[field.Name] == 1 {
+				promoted = append(promoted, field)
+			}
+		}
+		for _, field := range depth {
+			hidden[field.Name] = true
+		}
+		fields = append(fields, promoted...)
+		embedded = embeddedStructs(depth)
+	}
+
+	return fields
+}
+
+// parseDeclaredFields returns the fields declared by st, which is reached from the
+// parsed struct through the embedded fields of path.
+func parseDeclaredFields(fset *token.FileSet, st *types.Struct, path []*Embedding) []*Field {
+	fields := make([]*Field, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		tag, err := parseTag(st.Tag(i))
+		field := st.Field(i)
+
+		fields[i] = &Field{
+			Name:      field.Name(),
+			Type:      field.Type(),
+			Tag:       tag,
+			TagError:  err,
+			Pos:       fset.Position(field.Pos()),
+			Path:      path,
+			embedded:  field.Embedded(),
+			pkg:       field.Pkg(),
+			structTag: st.Tag(i),
+		}
+	}
+
+	return fields
+}
+
+// embeddedStruct is a struct embedded in the parsed struct through path.
+type embeddedStruct struct {
+	typ  types.Type // the embedded struct type, without pointer
+	st   *types.Struct
+	path []*Embedding
+}
+
+// embeddedStructs returns the structs embedded by the fields.
+func embeddedStructs(fields []*Field) []*embeddedStruct {
+	var embedded []*embeddedStruct
+	for _, field := range fields {
+		if !field.embedded {
+			continue
+		}
+		typ, pointer := field.Type, false
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ, pointer = ptr.Elem(), true
+		}
+		st, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		path := make([]*Embedding, len(field.Path), len(field.Path)+1)
+		copy(path, field.Path)
+		path = append(path, &Embedding{Name: field.Name, Type: field.Type, Pointer: pointer, Pos: field.Pos, pkg: field.pkg, structTag: field.structTag})
+		embedded = append(embedded, &embeddedStruct{typ: typ, st: st, path: path})
+	}
+	return embedded
+}
+
+// hasTagKey reports whether the struct tag tag has the key key followed by a colon
+// where a key can start: at the start of the tag or after whitespace.
+func hasTagKey(tag, key string) bool {
+	for _, field := range strings.Fields(tag) {
+		if strings.HasPrefix(field, key+tagKeyValueSep) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTag parses the wrapper tag of a field. It returns nil if the field has no wrapper
+// tag, and an error if the tag is malformed along with the settings it could parse.
+func parseTag(tag string) (*Tag, error) {
+	tag = strings.Trim(tag, "`")
+	tagStr, ok := reflect.StructTag(tag).Lookup(wrapperTag)
+	if !ok {
+		if hasTagKey(tag, wrapperTag) {
+			return nil, fmt.Errorf("tag %s is not in the key:\"value\" format", tag)
+		}
+		return nil, nil
+	}
+
+	var getter, setter, lock *string
+	var atomic, copyValues bool
+	var tagErr error
+
+	tags := strings.Split(tagStr, tagSep)
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			continue
+		}
+		keyValue := strings.Split(tag, tagKeyValueSep)
+		key := strings.TrimSpace(keyValue[0])
+		if len(keyValue) > 2 {
+			tagErr = fmt.Errorf("%q has more than one %q", tag, tagKeyValueSep)
+			continue
+		}
+
+		var rawValue, value string
+		if len(keyValue) == 2 {
+			rawValue = strings.TrimSpace(keyValue[1])
+			if rawValue != ignoreTag {
+				value = rawValue
+			}
+			if value != "" && !token.IsIdentifier(value) {
+				tagErr = fmt.Errorf("%s name %q is not an identifier", key, value)
+				continue
+			}
+		}
+		if (key == tagKeyGetter || key == tagKeySetter) && value == tagKeyCopy {
+			// getter:copy and setter:copy copy the values with the default method names
+			value, copyValues = "", true
+		}
+		switch key {
+		case tagKeyGetter:
+			getter = &value
+		case tagKeySetter:
+			setter = &value
+		case tagKeyLock:
+			// keep "-" to tell a field without lock from a field with the default lock
+			lock = &rawValue
+		case tagKeyAtomic:
+			if len(keyValue) == 2 {
+				tagErr = fmt.Errorf("%s takes no value", tagKeyAtomic)
+			}
+			atomic = true
+		case tagKeyCopy:
+			if len(keyValue) == 2 {
+				tagErr = fmt.Errorf("%s takes no value", tagKeyCopy)
+			}
+			copyValues = true
+		case ignoreTag:
+			// the field is ignored like a field without wrapper tag
+		default:
+			tagErr = fmt.Errorf("unknown key %q; use %s, %s, %s, %s or %s", key, tagKeyGetter, tagKeySetter, tagKeyLock, tagKeyAtomic, tagKeyCopy)
+		}
+	}
+
+	return &Tag{Setter: setter, Getter: getter, Lock: lock, Atomic: atomic, Copy: copyValues}, tagErr
+}
@@ -0,0 +1,42 @@
+package wrapper
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// registryFile is the name of the file holding the registry of a package.
+const registryFile = "type_wrapper_registry.go"
+
+// registryFilePath returns the path of the registry file in dir; the registry
+// follows the wrappers when they are written to stdout.
+func (g *generator) registryFilePath(dir string) string {
+	if g.output == stdoutOutput {
+		return stdoutOutput
+	}
+	return filepath.Join(dir, registryFile)
+}
+
+// checkRegistry reports an error when the wrapper of st cannot be registered.
+func (g *generator) checkRegistry(st *Struct) error {
+	if !g.reader && !g.writer {
+		return errors.New("registry requires -reader or -writer")
+	}
+	if st.TypeParams.Len() > 0 {
+		return fmt.Errorf("generic type %s cannot be registered", st.Name)
+	}
+	return nil
+}
+
+// generateRegistration generates the registration of the wrapper in the registry.
+func (g *generator) generateRegistration(
+	params *genParameters,
+) (string, error) {
+	return g.execute("registration", params)
+}
+
+// generateRegistry generates the registry shared by the wrappers of a package.
+func (g *generator) generateRegistry() (string, error) {
+	return g.execute("registry", g.codec)
+}
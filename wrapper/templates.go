@@ -0,0 +1,136 @@
+package wrapper
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// defaultTemplates are the built-in templates of the generated code, one per file.
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+const templateExt = ".tmpl"
+
+// templateFuncs are the helper functions of the templates.
+var templateFuncs = template.FuncMap{
+	"exportable":   makeExportable,
+	"unexportable": makeUnexportable,
+	"snake":        snakeCase,
+	"typeKind":     typeKind,
+	"elemType":     elemType,
+}
+
+// loadTemplates returns the built-in templates, overridden by the templates of dir if it is
+// set. The file name.tmpl of dir overrides the template name, and can define the templates
+// shared by the others.
+func loadTemplates(dir string) (*template.Template, error) {
+	t, err := template.New("").Funcs(templateFuncs).ParseFS(defaultTemplates, "templates/*"+templateExt)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return t, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+templateExt))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no %s files found in templates directory %s", templateExt, dir)
+	}
+	for _, path := range paths {
+		if t.Lookup(filepath.Base(path)) == nil {
+			return nil, fmt.Errorf("unknown template %s; use one of %s", path, strings.Join(templateNames(), ", "))
+		}
+	}
+
+	return t.ParseFiles(paths...)
+}
+
+// templateNames returns the sorted names of the built-in templates.
+func templateNames() []string {
+	entries, _ := defaultTemplates.ReadDir("templates")
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = strings.TrimSuffix(entry.Name(), templateExt)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// execute executes the template name with data.
+func (g *generator) execute(name string, data interface{}) (string, error) {
+	buf := new(bytes.Buffer)
+	if err := g.templates.ExecuteTemplate(buf, name+templateExt, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// TypeString returns the type t as written in the package of the wrapper.
+func (p *genParameters) TypeString(t types.Type) string {
+	return p.imports.typeString(p.src, t)
+}
+
+func makeUnexportable(name string) string {
+	return strings.ToLower(name[0:1]) + name[1:]
+}
+
+var (
+	firstCapMatcher   = regexp.MustCompile("(.)([A-Z][a-z]+)")
+	articleCapMatcher = regexp.MustCompile("([a-z0-9])([A-Z])")
+)
+
+// snakeCase returns name in snake_case: TestStruct is test_struct.
+func snakeCase(name string) string {
+	name = firstCapMatcher.ReplaceAllString(name, "${1}_${2}")
+	name = articleCapMatcher.ReplaceAllString(name, "${1}_${2}")
+	return strings.ToLower(name)
+}
+
+// typeKind returns the kind of the underlying type of t: array, basic, chan, func,
+// interface, map, pointer, slice, struct or typeparam.
+func typeKind(t types.Type) string {
+	if _, ok := t.(*types.TypeParam); ok {
+		return "typeparam"
+	}
+	switch t.Underlying().(type) {
+	case *types.Array:
+		return "array"
+	case *types.Basic:
+		return "basic"
+	case *types.Chan:
+		return "chan"
+	case *types.Signature:
+		return "func"
+	case *types.Interface:
+		return "interface"
+	case *types.Map:
+		return "map"
+	case *types.Pointer:
+		return "pointer"
+	case *types.Slice:
+		return "slice"
+	case *types.Struct:
+		return "struct"
+	}
+	return ""
+}
+
+// elemType returns the element type of an array, chan, map, pointer or slice type t,
+// or nil for the other types.
+func elemType(t types.Type) types.Type {
+	if elem, ok := t.Underlying().(interface{ Elem() types.Type }); ok {
+		return elem.Elem()
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package wrapper
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+)
+
+// trackMethods are the names of the methods of the wrappers generated with -track-changes.
+var trackMethods = []string{"Changed", "IsChanged", "ClearChanges"}
+
+// changedJSONMethod is the name of the method encoding the changed fields, which the
+// wrappers generated with -changed-json have.
+const changedJSONMethod = "ChangedJSON"
+
+// changeParameters describes a field whose setters mark it changed, by setting its bit
+// in the bitset of the wrapper.
+type changeParameters struct {
+	Field       string
+	JSONName    string   // name of the field in the JSON encoding, empty if encoding/json leaves it out
+	JSONParents []string // names of the JSON objects of the embedded structs nesting the field, outermost first
+	Word        int      // index of the word of the bitset holding the bit of the field
+	Mask        string   // mask of the bit of the field in its word, such as 1 << 3
+
+	// set once the accessors of the field are set up, used only by ChangedJSON
+	Selector  string
+	NilGuards []*nilGuard
+	Load      *atomicCall // loads the value of an atomic field
+}
+
+// tracksChanges reports whether setters mark their field changed, which -changed-json implies.
+func (g *generator) tracksChanges() bool {
+	return g.trackChanges || g.changedJSON
+}
+
+// setupChanges sets the parameters of the wrapper of st used to track the changes of its
+// fields, which are the fields with a setter planned in plan, including the atomic methods
+// kept when -collisions skip drops the setter itself, and returns them by field.
+func (g *generator) setupChanges(st *Struct, plan map[*Field]fieldMethods, params *genParameters) map[*Field]*changeParameters {
+	params.TrackChanges = true
+	params.ChangedJSON = g.changedJSON
+	changes := make(map[*Field]*changeParameters)
+	for _, field := range st.Fields {
+		methods, ok := plan[field]
+		if !ok || len(methods.setters()) == 0 {
+			continue
+		}
+		i := len(params.Changes)
+		change := &changeParameters{
+			Field: field.Name,
+			Word:  i / 64,
+			Mask:  fmt.Sprintf("1 << %d", i%64),
+		}
+		change.JSONParents, change.JSONName = jsonPath(field)
+		params.Changes = append(params.Changes, change)
+		changes[field] = change
+	}
+	params.ChangeWords = (len(params.Changes) + 63) / 64
+	return changes
+}
+
+// JSONChanges returns the tracked fields encoded by ChangedJSON, which are those
+// encoding/json encodes.
+func (p *genParameters) JSONChanges() []*changeParameters {
+	var changes []*changeParameters
+	for _, change := range p.Changes {
+		if change.JSONName != "" {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// NestsJSON reports whether ChangedJSON encodes a field in the object of an embedded struct.
+func (p *genParameters) NestsJSON() bool {
+	for _, change := range p.JSONChanges() {
+		if len(change.JSONParents) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONObject returns the expression of the object of ChangedJSON holding the field, which
+// is value, or the objects of the embedded structs nesting the field looked up by object.
+func (c *changeParameters) JSONObject() string {
+	object := "value"
+	for _, name := range c.JSONParents {
+		object = fmt.Sprintf("object(%s, %q)", object, name)
+	}
+	return object
+}
+
+// jsonPath returns the names of the objects nesting field in its JSON encoding and its
+// name there, following encoding/json: unexported fields and fields tagged "-" are left
+// out, and the fields of embedded structs are nested in an object if the json tag of
+// the embedded field has a name, and promoted otherwise. The name is empty if the
+// field is left out, which embedded structs are as they have no name of their own.
+func jsonPath(field *Field) (parents []string, name string) {
+	for _, e := range field.Path {
+		name, ok := jsonTagName(e.structTag)
+		if !ok {
+			return nil, ""
+		}
+		if name != "" {
+			parents = append(parents, name)
+		}
+	}
+
+	name, ok := jsonTagName(field.structTag)
+	switch {
+	case !ok, !field.embedded && !token.IsExported(field.Name):
+		return nil, ""
+	case name != "":
+		return parents, name
+	case field.embedded:
+		t := field.Type
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if _, ok := t.Underlying().(*types.Struct); ok || !token.IsExported(field.Name) {
+			return nil, ""
+		}
+	}
+	return parents, field.Name
+}
+
+// jsonTagName returns the name of the json tag of a field with the tag structTag, which
+// is empty if the tag sets none, and reports whether encoding/json encodes the field.
+func jsonTagName(structTag string) (string, bool) {
+	tag := reflect.StructTag(structTag).Get("json")
+	if tag == ignoreTag {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, true
+}
@@ -0,0 +1,124 @@
+package wrapper
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Package is a parsed package and its struct types.
+type Package struct {
+	*packages.Package
+	Dir     string // directory of the files of the package, where wrappers are written
+	Structs []*Struct
+
+	foreign map[string]*Package        // packages of the structs of other packages, by import path
+	aliases map[string]map[string]bool // names of the imports of the files, by import path
+}
+
+// Struct is a struct type declared in a package.
+type Struct struct {
+	Name       string
+	Type       types.Type // the defined type of the struct
+	TypeParams *types.TypeParamList
+	Fields     []*Field
+	Pos        token.Position
+
+	pkg *Package // package declaring the struct if it is wrapped in another package
+}
+
+// Field is a field of a struct type, declared by the struct or promoted from
+// one of its embedded structs.
+type Field struct {
+	Type     types.Type
+	Tag      *Tag
+	TagError error // set if the wrapper tag is malformed
+	Name     string
+	Pos      token.Position
+	Path     []*Embedding // embedded fields leading to a promoted field, outermost first
+
+	embedded  bool           // the field is an embedded field
+	pkg       *types.Package // package declaring the field
+	structTag string         // the whole tag of the field, such as `json:"name" wrapper:"getter"`
+}
+
+// Embedding is an embedded struct field on the path to a promoted field.
+type Embedding struct {
+	Name    string     // name of the embedded field, which is the name of its type
+	Type    types.Type // type of the embedded field, a struct or a pointer to a struct
+	Pointer bool       // the field embeds a pointer, which may be nil
+	Pos     token.Position
+
+	pkg       *types.Package // package declaring the field
+	structTag string         // the whole tag of the field
+}
+
+// Tag is the parsed wrapper tag of a field.
+type Tag struct {
+	Getter *string
+	Setter *string
+	Lock   *string // "-" if the field must not be locked
+	Atomic bool
+	Copy   bool // accessors copy the values of slices, maps and pointers
+}
+
+// lookupStruct returns the struct named name, or nil if pkg has no such struct.
+func (pkg *Package) lookupStruct(name string) *Struct {
+	for _, st := range pkg.Structs {
+		if st.Name == name {
+			return st
+		}
+	}
+	return nil
+}
+
+// HasStruct reports whether pkg declares the struct type named name.
+func (pkg *Package) HasStruct(name string) bool {
+	return pkg.lookupStruct(name) != nil
+}
+
+// HasTaggedStruct reports whether pkg declares a struct with a wrapper tag.
+func (pkg *Package) HasTaggedStruct() bool {
+	for _, st := range pkg.Structs {
+		if st.hasTag() {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupField returns the field named name, or nil if st has no such field.
+func (st *Struct) lookupField(name string) *Field {
+	for _, field := range st.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// hasTag reports whether any field of st has a wrapper tag.
+func (st *Struct) hasTag() bool {
+	for _, field := range st.Fields {
+		if field.Tag != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// promoted reports whether field is promoted from an embedded struct.
+func (field *Field) promoted() bool {
+	return len(field.Path) > 0
+}
+
+// throughPointer reports whether field is promoted through an embedded pointer.
+func (field *Field) throughPointer() bool {
+	for _, e := range field.Path {
+		if e.Pointer {
+			return true
+		}
+	}
+	return false
+}
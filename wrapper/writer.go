@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"io"
 
 	"github.com/spf13/afero"
 )
@@ -12,6 +13,7 @@ type writer struct {
 	buf        *bytes.Buffer
 	fs         afero.Fs
 	outputFile string
+	out        io.Writer // written instead of outputFile if set
 }
 
 func newWriter(fs afero.Fs, outputFile string) *writer {
@@ -22,18 +24,42 @@ func newWriter(fs afero.Fs, outputFile string) *writer {
 	}
 }
 
+// newStdoutWriter returns a writer writing the generated code to out.
+func newStdoutWriter(out io.Writer) *writer {
+	return &writer{
+		buf:        new(bytes.Buffer),
+		outputFile: stdoutOutput,
+		out:        out,
+	}
+}
+
 func (w *writer) printf(format string, args ...interface{}) {
 	fmt.Fprintf(w.buf, format, args...)
 }
 
 func (w *writer) write(pkgName string, imports []string, wrappers []string) error {
+	content, err := w.render(pkgName, imports, wrappers)
+	if err != nil {
+		return err
+	}
+
+	if w.out != nil {
+		_, err = w.out.Write(content)
+		return err
+	}
+
+	return afero.WriteFile(w.fs, w.outputFile, content, 0644)
+}
+
+// render returns the formatted source of the file.
+func (w *writer) render(pkgName string, imports []string, wrappers []string) ([]byte, error) {
 	w.printf("// Code generated by type-wrapper; DO NOT EDIT.\n")
 	w.printf("package %s\n\n", pkgName)
 
 	if len(imports) > 0 {
 		w.printf("import (\n")
 		for i := range imports {
-			w.printf("\t\"%s\"\n", imports[i])
+			w.printf("\t%s\n", imports[i])
 		}
 		w.printf(")\n")
 	}
@@ -42,12 +68,7 @@ func (w *writer) write(pkgName string, imports []string, wrappers []string) erro
 		w.printf("%s\n", wrappers[i])
 	}
 
-	content, err := w.format()
-	if err != nil {
-		return err
-	}
-
-	return afero.WriteFile(w.fs, w.outputFile, content, 0644)
+	return w.format()
 }
 
 func (w *writer) format() ([]byte, error) {